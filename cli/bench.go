@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/mocks/caravela"
+	"github.com/strabox/caravela-sim/mocks/overlay"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/urfave/cli"
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// maxBenchLookupsInputs maximum number of different lookups (origin node and key) generated for the benchmark.
+const maxBenchLookupsInputs = 65536
+
+// bench measures the throughput of the overlay mock's lookups, which dominate the CPU usage of large simulations.
+func bench(c *cli.Context) {
+	configFilePath := c.GlobalString("config")
+
+	simulatorConfigs, err := configuration.ReadFromFile(configFilePath)
+	if err != nil {
+		util.Log.Errorf("Cannot read config file %s, error: %s", configFilePath, err)
+		fmt.Println("Information: Using the default configurations!!")
+		simulatorConfigs = configuration.Default()
+	}
+
+	overrideSimFileConfigs(c, simulatorConfigs)
+	numLookups := c.Int("lookups")
+	if numLookups <= 0 {
+		util.Log.Errorf("Invalid number of lookups: %d", numLookups)
+		return
+	}
+
+	numNodes := simulatorConfigs.TotalNumberOfNodes()
+	caravelaConfigs := caravela.Configuration()
+	caravela.Init(simulatorConfigs.CaravelaLogsLevel(), caravelaConfigs)
+	metricsCollector := metrics.NewCollector(numNodes, simulatorConfigs.OutDirectoryPath, simulatorConfigs)
+
+	// Base seed for the overlay and the lookups' pseudo-random generators.
+	baseRngSeed := time.Now().UnixNano()
+
+	fmt.Println("Initializing overlay...")
+	initStart := time.Now()
+	overlayMock := overlay.CreateOverlayMock(simulatorConfigs, caravelaConfigs, baseRngSeed, metricsCollector)
+	initDuration := time.Since(initStart)
+	metricsCollector.InitNewSimulation("bench", make([]types.Resources, numNodes))
+
+	// The lookups' inputs are generated beforehand in order to only measure the lookups.
+	randomGenerator := rand.New(rand.NewSource(baseRngSeed))
+	numInputs := numLookups
+	if numInputs > maxBenchLookupsInputs {
+		numInputs = maxBenchLookupsInputs
+	}
+	contexts := make([]context.Context, numInputs)
+	keys := make([][]byte, numInputs)
+	for i := range contexts {
+		tradersGUIDs := overlayMock.NodeTradersGUIDs(randomGenerator.Intn(numNodes))
+		fromGUID := guid.NewGUIDBytes(tradersGUIDs[randomGenerator.Intn(len(tradersGUIDs))])
+		contexts[i] = context.WithValue(context.Background(), types.NodeGUIDKey, fromGUID.String())
+		keys[i] = make([]byte, guid.SizeBytes())
+		randomGenerator.Read(keys[i])
+	}
+
+	fmt.Println("Running lookups...")
+	var memStatsBefore, memStatsAfter runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&memStatsBefore)
+	if cpuProfilePath := c.String("cpuprofile"); cpuProfilePath != "" {
+		cpuProfileFile, err := os.Create(cpuProfilePath)
+		if err != nil {
+			util.Log.Errorf("Cannot create CPU profile file %s, error: %s", cpuProfilePath, err)
+			return
+		}
+		defer cpuProfileFile.Close()
+		if err := pprof.StartCPUProfile(cpuProfileFile); err != nil {
+			util.Log.Errorf("Cannot start CPU profile, error: %s", err)
+			return
+		}
+	}
+	lookupsStart := time.Now()
+	for i := 0; i < numLookups; i++ {
+		overlayMock.Lookup(contexts[i%numInputs], keys[i%numInputs])
+	}
+	lookupsDuration := time.Since(lookupsStart)
+	pprof.StopCPUProfile()
+	runtime.ReadMemStats(&memStatsAfter)
+
+	fmt.Printf("##################################################################\n")
+	fmt.Printf("Overlay:                %s\n", simulatorConfigs.OverlayMock())
+	fmt.Printf("Nodes:                  %d\n", numNodes)
+	fmt.Printf("Overlay Init:           %s\n", initDuration)
+	fmt.Printf("Lookups:                %d\n", numLookups)
+	fmt.Printf("Lookups per Second:     %.0f\n", float64(numLookups)/lookupsDuration.Seconds())
+	fmt.Printf("Time per Lookup:        %s\n", lookupsDuration/time.Duration(numLookups))
+	fmt.Printf("Allocations per Lookup: %.2f\n",
+		float64(memStatsAfter.Mallocs-memStatsBefore.Mallocs)/float64(numLookups))
+	fmt.Printf("Bytes per Lookup:       %.1f\n",
+		float64(memStatsAfter.TotalAlloc-memStatsBefore.TotalAlloc)/float64(numLookups))
+	fmt.Printf("##################################################################\n")
+}
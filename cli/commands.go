@@ -12,5 +12,25 @@ var (
 			Before:    printBanner,
 			Action:    start,
 		},
+		{
+			Name:      "bench",
+			ShortName: "b",
+			Usage:     "Benchmark the overlay's lookups",
+			Category:  "Simulator management",
+			Before:    printBanner,
+			Action:    bench,
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "lookups, n",
+					Value: 1000000,
+					Usage: "Number of lookups performed",
+				},
+				cli.StringFlag{
+					Name:  "cpuprofile",
+					Value: "",
+					Usage: "File where the CPU profile of the lookups is written",
+				},
+			},
+		},
 	}
 )
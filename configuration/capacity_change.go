@@ -0,0 +1,30 @@
+package configuration
+
+import "fmt"
+
+// CapacityChange represents a scheduled change of the capacity of a set of nodes (e.g. the owners reclaim
+// part of their resources).
+type CapacityChange struct {
+	Tick            int     // Tick when the change happens.
+	NodesPercentage float64 // Percentage of the nodes, chosen at random, affected by the change.
+	CPUsFactor      float64 // Factor of the node's original CPUs available after the change.
+	MemoryFactor    float64 // Factor of the node's original memory available after the change.
+	Duration        int     // Ticks until the original capacity is restored (0 means it is permanent).
+}
+
+// validate verifies if the capacity change is valid.
+func (c *CapacityChange) validate() error {
+	if c.Tick < 0 {
+		return fmt.Errorf("capacity change with a negative tick: %d", c.Tick)
+	}
+	if c.NodesPercentage <= 0 || c.NodesPercentage > 100 {
+		return fmt.Errorf("capacity change nodes percentage must be in ]0, 100]: %f", c.NodesPercentage)
+	}
+	if c.CPUsFactor < 0 || c.MemoryFactor < 0 {
+		return fmt.Errorf("capacity change with negative factors: %f, %f", c.CPUsFactor, c.MemoryFactor)
+	}
+	if c.Duration < 0 {
+		return fmt.Errorf("capacity change with a negative duration: %d", c.Duration)
+	}
+	return nil
+}
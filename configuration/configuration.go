@@ -11,11 +11,50 @@ import (
 // TODO
 const DefaultRequestFeeder = "random"
 
+// Default policy used to select the containers stopped by the stop requests.
+const DefaultVictimPolicy = "lifo"
+
+// Default normalization of the resources read by the json feeder.
+const DefaultJsonNormalization = "largest-node"
+
+// Default factor applied to the resources read by the json feeder.
+const DefaultJsonScaleFactor = 4.0
+
+// Default CPU class assignment of the json feeder.
+const DefaultJsonCPUClassAssignment = "fixed"
+
+// Default proportional gain of the target feeder's controller.
+const DefaultTargetKp = 0.5
+
+// Default integral gain of the target feeder's controller.
+const DefaultTargetKi = 0.05
+
 // TODO
 const DefaultResourceGenerator = "partition-fit"
 
-// TODO
-const DefaultSpeedupNodes = 300
+// Default placement of the nodes' GUIDs in the chord's ring.
+const DefaultGUIDPlacement = "uniform"
+
+// Default overlay mocked to connect the nodes.
+const DefaultOverlayMock = "chord"
+
+// Default size of the kademlia's k-buckets.
+const DefaultKademliaBucketSize = 20
+
+// Default number of parallel queries in each kademlia's lookup round.
+const DefaultKademliaAlpha = 3
+
+// Default placement of the nodes' network coordinates (none disables the latency between the nodes).
+const DefaultNetworkCoordinates = "none"
+
+// Default side of the network coordinates' space (in milliseconds).
+const DefaultNetworkSpaceSize = 200
+
+// Default size model of the messages traded between the nodes (the REST API's encoding).
+const DefaultMessageSizeModel = "json"
+
+// Default size (in bytes) of the messages whose type has no fixed size in the fixed size model.
+const DefaultMessageFixedSize = 256
 
 // Default name of the configuration file.
 const DefaultConfigFilePath = "simulation.toml"
@@ -26,22 +65,37 @@ const DefaultSimLogLevel = "panic"
 // TODO
 const DefaultCaravelaLogLevel = "info"
 
+// Default capacity (MB) of the nodes' image cache
+const DefaultImageCacheSize = 10240
+
+// Default port of the first node serving the REST API (Caravela's default API port)
+const DefaultAPIBasePort = 8001
+
 // Default Simulator's out directory path
 const DefaultOutDirectoryPath = "out"
 
 // Configuration structure with initialization parameters for the simulator.
 type Configuration struct {
-	NumberOfNodes      int      // Number of nodes used in the engine.
-	TickInterval       duration // Interval between each simulator tick (in engine time).
-	MaxTicks           int      // Maximum number of ticks done by the simulator.
-	Multithread        bool     // Used to leverage the multiple cores to speed up the engine.
-	DiscoveryBackends  []string // The discovery backends to simulate
-	RequestFeeder      requestFeeder
-	ResourcesGenerator resourcesGenerator // Strategies used to generate the resources for each node.
-	ChordMock          chordMock
-	OutDirectoryPath   string // Path of the output's directory.
-	SimulatorLogLevel  string // Log's level of the simulator.
-	CaravelaLogLevel   string // Log's level of the CARAVELA's system.
+	NumberOfNodes       int      // Number of nodes used in the engine.
+	TickInterval        duration // Interval between each simulator tick (in engine time).
+	MaxTicks            int      // Maximum number of ticks done by the simulator.
+	Multithread         bool     // Used to leverage the multiple cores to speed up the engine.
+	DiscoveryBackends   []string // The discovery backends to simulate
+	RequestFeeder       requestFeeder
+	ResourcesGenerator  resourcesGenerator // Strategies used to generate the resources for each node.
+	CapacityChanges     capacityChanges    // Changes of the nodes' capacity during the simulation.
+	ContainerEvents     containerEvents    // Containers ending on their own (reported by the docker engine's events).
+	Images              images             // Images of the containers and the nodes' image caches.
+	Overlay             string             // Overlay mocked to connect the nodes: chord or kademlia.
+	ChordMock           chordMock
+	KademliaMock        kademliaMock
+	Network             network      // Network coordinates of the nodes, i.e. the latency between them.
+	MessageSizes        messageSizes // Model of the messages' size used in the bandwidth's accounting.
+	API                 api          // Simulated nodes that serve the Caravela's REST API.
+	OutDirectoryPath    string       // Path of the output's directory.
+	LookupPathsSampling int          // One of each N overlay's lookups has its path captured (0 disables it).
+	SimulatorLogLevel   string       // Log's level of the simulator.
+	CaravelaLogLevel    string       // Log's level of the CARAVELA's system.
 }
 
 // TODO
@@ -50,17 +104,162 @@ type requestFeeder struct {
 	RequestsProfile    []RequestProfile
 	DeployRequestsRate []float64
 	StopRequestsRate   []float64
+	Tenants            []Tenant       // Tenants that submit the requests (when the feeder doesn't provide them).
+	VictimPolicy       string         // Selects the container stopped by each stop request: lifo, fifo, random, oldest or node.
+	Source             string         // Label of the requests in the metrics (by default it is the feeder's name).
+	Sources            []feederSource // Workload sources combined by the mix feeder.
+	Json               jsonFeeder     // Configurations of the json feeder.
+	Target             targetFeeder   // Configurations of the target feeder.
+	Process            processFeeder  // Configurations of the process feeder.
+}
+
+// feederSource describes one of the workload sources that the mix feeder combines.
+// The tenants, requests profile and rates are inherited from the request feeder when they are not set.
+type feederSource struct {
+	Name               string // Label used to tag the source's requests in the metrics.
+	RequestFeeder      string // Feeder used to generate the source's requests.
+	Tenants            []Tenant
+	RequestsProfile    []RequestProfile
+	DeployRequestsRate []float64
+	StopRequestsRate   []float64
+}
+
+// jsonFeeder holds the configurations used to translate the trace's requests into CARAVELA's requests.
+// The largest node is the largest CPUs and memory of Caravela's resources partitions, whatever the resources
+// generator used.
+type jsonFeeder struct {
+	Normalization      string  // Reference of the trace's resources: absolute, largest-node or profile.
+	ScaleFactor        float64 // Factor applied to the trace's resources.
+	CPUClassAssignment string  // How the CPU class is chosen: fixed, ratio, priority or scheduling-class.
+	CPUClass           int     // CPU class used by the fixed assignment.
+	CPUClassThresholds []int   // Minimum trace's field value for each CPU class (priority and scheduling-class).
+}
+
+// targetFeeder holds the configurations of the feeder that drives the system into a target utilization.
+type targetFeeder struct {
+	TargetUtilization []float64 // Sequence of utilization targets (%) spread evenly over the simulation.
+	ChurnRate         float64   // Deploy and stop requests issued in every tick independently of the error (% of nodes).
+	MaxRequestsRate   float64   // Maximum deploy or stop requests issued in a tick (% of nodes).
+	Kp                float64   // Proportional gain of the controller.
+	Ki                float64   // Integral gain of the controller.
+}
+
+// processFeeder holds the configurations of the feeder driven by an external process.
+type processFeeder struct {
+	Command string   // Executable of the process that generates the requests.
+	Args    []string // Arguments passed to the process.
 }
 
 // TODO
 type resourcesGenerator struct {
 	ResourceGenerator string
 	StaticResources   types.Resources
+	Trace             nodesTrace        // Configurations of the trace (CSV) resource generator.
+	Distribution      nodesDistribution // Configurations of the distribution resource generator.
+}
+
+// capacityChanges holds the configurations of the scheduled and random changes of the nodes' capacity.
+type capacityChanges struct {
+	Scheduled       []CapacityChange // Changes that happen in a specific tick.
+	RandomRate      float64          // Percentage of the nodes whose capacity changes randomly in each tick.
+	RandomResources string           // Resources affected by the random changes: cpus, memory or both.
+	RandomMinFactor float64          // Minimum factor of the node's original capacity after a random change.
+	RandomMaxFactor float64          // Maximum factor of the node's original capacity after a random change.
+	RandomDuration  int              // Ticks until a random change is restored (0 means it is permanent).
+}
+
+// containerEvents holds the distributions of the time (in ticks) until the containers end on their own, which is
+// reported by the docker engine through its events. Each container ends at the earliest of the times drawn from
+// the configured distributions, the unset ones never happen.
+type containerEvents struct {
+	Lifetime    Distribution // Time until the container finishes its work.
+	TimeToCrash Distribution // Time until the container crashes.
+	TimeToOOM   Distribution // Time until the container is killed for running out of memory.
+}
+
+// images holds the configurations of the containers' images: the catalogue of images requested and the image
+// cache of each node, whose misses pull the image from the registry.
+type images struct {
+	Catalogue     []Image  // Images used by the requests (when empty the images aren't simulated).
+	CacheSize     int      // Capacity (MB) of each node's image cache.
+	PullBandwidth float64  // Bandwidth (MB/s) of a node pulling an image from the registry.
+	PullLatency   duration // Latency of each pull, added to the image's transfer time.
+}
+
+// nodesDistribution holds the distributions used to draw the nodes' capacities.
+type nodesDistribution struct {
+	CPUClass    int          // CPU class of all the nodes.
+	CPUs        Distribution // Distribution of the node's CPUs (in cores).
+	Memory      Distribution // Distribution of the node's memory (in MB).
+	Correlation float64      // Correlation between the node's CPUs and memory (Gaussian copula).
+}
+
+// nodesTrace holds the configurations used to read the nodes' capacities from a CSV file.
+type nodesTrace struct {
+	FilePath        string         // Path of the CSV file.
+	HasHeader       bool           // True if the first row of the file is a header.
+	CPUsColumn      int            // Index of the column with the node's CPUs.
+	MemoryColumn    int            // Index of the column with the node's memory.
+	PlatformColumn  int            // Index of the column with the node's CPU platform (-1 if there isn't one).
+	FilterColumn    int            // Index of the column used to filter the rows (-1 to use all the rows).
+	FilterValue     string         // Value that the filter column must have (e.g. the machine add event).
+	CPUsScale       float64        // Factor that converts the trace's CPUs into cores.
+	MemoryScale     float64        // Factor that converts the trace's memory into MB.
+	Assignment      string         // How the rows are assigned to the nodes: sequential or sample.
+	PlatformsClass  map[string]int // CPU class of each CPU platform.
+	DefaultCPUClass int            // CPU class of the nodes with an unknown platform.
 }
 
 // TODO
 type chordMock struct {
-	SpeedupNodes int
+	GUIDPlacement string     // Placement of the nodes' GUIDs in the ring: uniform or hashed (random node identities).
+	Churn         chordChurn // Nodes joining and leaving the ring during the simulation.
+}
+
+// kademliaMock holds the configurations of the kademlia's overlay mock.
+type kademliaMock struct {
+	BucketSize int // Maximum number of contacts in each k-bucket (k), also the size of the lookups' results.
+	Alpha      int // Number of nodes queried in parallel in each round of a lookup.
+}
+
+// network holds the configurations of the nodes' network coordinates. The euclidean distance between two
+// nodes' coordinates is the latency (in milliseconds) between them.
+type network struct {
+	Coordinates         string  // Placement of the nodes' coordinates: none, random, clustered or file.
+	Dimensions          int     // Dimensions of the coordinates' space.
+	SpaceSize           float64 // Side of the coordinates' space (ms), used by the random and clustered placements.
+	Clusters            int     // Number of clusters of the clustered placement.
+	ClusterRadius       float64 // Standard deviation (ms) of the distance between the nodes and their cluster's center.
+	FilePath            string  // Path of the CSV file with the coordinates of a node per row (file placement).
+	ProximityCandidates int     // Nodes considered when choosing each overlay's finger/contact by latency (0 disables it).
+}
+
+// messageSizes holds the configurations of the model used to measure the size of the messages traded between
+// the nodes, i.e. the wire format of a protocol.
+type messageSizes struct {
+	Model        string         // Size model of the messages: json, binary, gzip or fixed.
+	CacheSamples int            // Messages measured per type before using the type's average size (0 measures all).
+	FixedSizes   map[string]int // Size (bytes) of each message type in the fixed model.
+	DefaultSize  int            // Size (bytes) of the message types without a size in the fixed model.
+}
+
+// api holds the configurations of the simulated nodes that serve the Caravela's REST API in loopback ports,
+// so the caravela's CLI (or other tools) can be used with them while the simulation runs.
+type api struct {
+	Nodes     []int    // Indexes of the nodes that serve the REST API (when empty the API isn't served).
+	BasePort  int      // Port of the first node, the other nodes use the following ports.
+	TickDelay duration // Real time waited after each tick, to slow down the simulation for interactive use.
+}
+
+// chordChurn holds the configurations of the nodes joining and leaving the chord's ring.
+// Only the overlay membership changes, the CARAVELA's nodes keep running.
+type chordChurn struct {
+	InitialNodes   float64 // Percentage of the nodes that are in the ring at the start.
+	JoinRate       float64 // Percentage of the nodes that join the ring in each tick.
+	LeaveRate      float64 // Percentage of the nodes that leave the ring in each tick.
+	Repair         string  // How the ring is repaired: instant (idealised) or periodic (stabilize and fix fingers).
+	StabilizeTicks int     // Ticks between each stabilization of the periodic repair.
+	FixFingers     int     // Fingers fixed by each node in each stabilization of the periodic repair.
 }
 
 // Default creates the configuration structure for a basic/default engine.
@@ -77,6 +276,8 @@ func Default() *Configuration {
 			RequestFeeder:      DefaultRequestFeeder,
 			DeployRequestsRate: []float64{0.025, 0.015, 0.010, 0.035, 0.02, 0.01, 0.01, 0.05},
 			StopRequestsRate:   []float64{0, 0, 0, 0, 0, 0.025, 0.015, 0.15},
+			Tenants:            make([]Tenant, 0),
+			VictimPolicy:       DefaultVictimPolicy,
 			RequestsProfile: []RequestProfile{
 				{CPUClass: 0, CPUs: 1, Memory: 256, Percentage: 20},
 				{CPUClass: 0, CPUs: 2, Memory: 1500, Percentage: 20},
@@ -87,6 +288,24 @@ func Default() *Configuration {
 				{CPUClass: 1, CPUs: 3, Memory: 2048, Percentage: 5},
 				{CPUClass: 1, CPUs: 6, Memory: 5000, Percentage: 5},
 			},
+			Json: jsonFeeder{
+				Normalization:      DefaultJsonNormalization,
+				ScaleFactor:        DefaultJsonScaleFactor,
+				CPUClassAssignment: DefaultJsonCPUClassAssignment,
+				CPUClass:           0,
+				CPUClassThresholds: make([]int, 0),
+			},
+			Target: targetFeeder{
+				TargetUtilization: []float64{30},
+				ChurnRate:         0.1,
+				MaxRequestsRate:   5,
+				Kp:                DefaultTargetKp,
+				Ki:                DefaultTargetKi,
+			},
+			Process: processFeeder{
+				Command: "",
+				Args:    make([]string, 0),
+			},
 		},
 		ResourcesGenerator: resourcesGenerator{
 			ResourceGenerator: DefaultResourceGenerator,
@@ -95,9 +314,81 @@ func Default() *Configuration {
 				CPUs:     4,
 				Memory:   4096,
 			},
+			Trace: nodesTrace{
+				FilePath:        "",
+				HasHeader:       false,
+				CPUsColumn:      0,
+				MemoryColumn:    1,
+				PlatformColumn:  -1,
+				FilterColumn:    -1,
+				FilterValue:     "",
+				CPUsScale:       1,
+				MemoryScale:     1,
+				Assignment:      "sequential",
+				PlatformsClass:  make(map[string]int),
+				DefaultCPUClass: 0,
+			},
+			Distribution: nodesDistribution{
+				CPUClass:    0,
+				CPUs:        Distribution{Type: "uniform", Min: 1, Max: 8},
+				Memory:      Distribution{Type: "uniform", Min: 1024, Max: 8192},
+				Correlation: 0,
+			},
+		},
+		CapacityChanges: capacityChanges{
+			Scheduled:       make([]CapacityChange, 0),
+			RandomRate:      0,
+			RandomResources: "both",
+			RandomMinFactor: 0.5,
+			RandomMaxFactor: 1,
+			RandomDuration:  0,
+		},
+		ContainerEvents: containerEvents{
+			Lifetime:    Distribution{},
+			TimeToCrash: Distribution{},
+			TimeToOOM:   Distribution{},
 		},
+		Images: images{
+			Catalogue:     make([]Image, 0),
+			CacheSize:     DefaultImageCacheSize,
+			PullBandwidth: 12.5,
+			PullLatency:   duration{Duration: 500 * time.Millisecond},
+		},
+		Overlay: DefaultOverlayMock,
 		ChordMock: chordMock{
-			SpeedupNodes: DefaultSpeedupNodes,
+			GUIDPlacement: DefaultGUIDPlacement,
+			Churn: chordChurn{
+				InitialNodes:   100,
+				JoinRate:       0,
+				LeaveRate:      0,
+				Repair:         "instant",
+				StabilizeTicks: 1,
+				FixFingers:     1,
+			},
+		},
+		KademliaMock: kademliaMock{
+			BucketSize: DefaultKademliaBucketSize,
+			Alpha:      DefaultKademliaAlpha,
+		},
+		Network: network{
+			Coordinates:         DefaultNetworkCoordinates,
+			Dimensions:          2,
+			SpaceSize:           DefaultNetworkSpaceSize,
+			Clusters:            8,
+			ClusterRadius:       10,
+			FilePath:            "",
+			ProximityCandidates: 0,
+		},
+		MessageSizes: messageSizes{
+			Model:        DefaultMessageSizeModel,
+			CacheSamples: 0,
+			FixedSizes:   make(map[string]int),
+			DefaultSize:  DefaultMessageFixedSize,
+		},
+		API: api{
+			Nodes:     make([]int, 0),
+			BasePort:  DefaultAPIBasePort,
+			TickDelay: duration{Duration: 0},
 		},
 	}
 }
@@ -107,9 +398,13 @@ func Default() *Configuration {
 func ReadFromFile(configFilePath string) (*Configuration, error) {
 	config := Default()
 
-	if _, err := toml.DecodeFile(configFilePath, config); err != nil {
+	metadata, err := toml.DecodeFile(configFilePath, config)
+	if err != nil {
 		return nil, err
 	}
+	if metadata.IsDefined("ChordMock", "SpeedupNodes") { // Kept only for compatibility with old files.
+		util.Log.Warnf("ChordMock.SpeedupNodes is ignored, the ring's successors are found with a binary search")
+	}
 
 	if err := config.validate(); err != nil {
 		return nil, err
@@ -137,6 +432,9 @@ func (c *Configuration) validate() error {
 	if c.MaxTicks <= 0 {
 		return fmt.Errorf("the number of maximum ticks must be > 0: %d", c.MaxTicks)
 	}
+	if c.LookupPathsSampling < 0 {
+		return fmt.Errorf("the lookup paths sampling must be >= 0: %d", c.LookupPathsSampling)
+	}
 
 	if len(c.DeployRequestsRate()) == 0 {
 		return fmt.Errorf("the sequence of deploy requests rate must have at least one rate")
@@ -146,8 +444,243 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("the sequence stop requests rate must have at least one rate")
 	}
 
-	if c.ChordMock.SpeedupNodes <= 0 {
-		return fmt.Errorf("the number of speedup nodes must be > 0: %d", c.MaxTicks)
+	for _, reqProfile := range c.RequestFeeder.RequestsProfile {
+		if err := reqProfile.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateTenants(c.RequestFeeder.Tenants); err != nil {
+		return err
+	}
+
+	victimPolicy := c.FeederVictimPolicy()
+	if victimPolicy != "lifo" && victimPolicy != "fifo" && victimPolicy != "random" && victimPolicy != "oldest" &&
+		victimPolicy != "node" {
+		return fmt.Errorf("invalid victim policy: %s", victimPolicy)
+	}
+
+	if c.Feeder() == "mix" {
+		if len(c.RequestFeeder.Sources) == 0 {
+			return fmt.Errorf("the mix request feeder must have at least one source")
+		}
+		sourcesNames := make(map[string]bool)
+		for _, source := range c.RequestFeeder.Sources {
+			if source.Name == "" {
+				return fmt.Errorf("the mix request feeder sources must have a name")
+			}
+			if sourcesNames[source.Name] {
+				return fmt.Errorf("duplicated mix request feeder source: %s", source.Name)
+			}
+			if source.RequestFeeder == "" || source.RequestFeeder == "mix" {
+				return fmt.Errorf("invalid request feeder for the mix source %s: %s", source.Name, source.RequestFeeder)
+			}
+			if err := validateTenants(source.Tenants); err != nil {
+				return err
+			}
+			for _, reqProfile := range source.RequestsProfile {
+				if err := reqProfile.validate(); err != nil {
+					return err
+				}
+			}
+			sourcesNames[source.Name] = true
+		}
+	}
+
+	jsonNormalization := c.JsonFeederNormalization()
+	if jsonNormalization != "absolute" && jsonNormalization != "largest-node" && jsonNormalization != "profile" {
+		return fmt.Errorf("invalid json feeder normalization: %s", jsonNormalization)
+	}
+
+	if c.JsonFeederScaleFactor() <= 0 {
+		return fmt.Errorf("the json feeder scale factor must be > 0: %f", c.JsonFeederScaleFactor())
+	}
+
+	jsonClassAssignment := c.JsonFeederCPUClassAssignment()
+	if jsonClassAssignment != "fixed" && jsonClassAssignment != "ratio" && jsonClassAssignment != "priority" &&
+		jsonClassAssignment != "scheduling-class" {
+		return fmt.Errorf("invalid json feeder CPU class assignment: %s", jsonClassAssignment)
+	}
+
+	if c.JsonFeederCPUClass() < 0 {
+		return fmt.Errorf("the json feeder CPU class must be >= 0: %d", c.JsonFeederCPUClass())
+	}
+
+	for i := 1; i < len(c.RequestFeeder.Json.CPUClassThresholds); i++ {
+		if c.RequestFeeder.Json.CPUClassThresholds[i] < c.RequestFeeder.Json.CPUClassThresholds[i-1] {
+			return fmt.Errorf("the json feeder CPU class thresholds must be in ascending order: %v",
+				c.RequestFeeder.Json.CPUClassThresholds)
+		}
+	}
+
+	if len(c.RequestFeeder.Target.TargetUtilization) == 0 {
+		return fmt.Errorf("the target feeder must have at least one utilization target")
+	}
+	for _, target := range c.RequestFeeder.Target.TargetUtilization {
+		if target < 0 || target > 100 {
+			return fmt.Errorf("the target feeder utilization targets must be in [0, 100]: %v", target)
+		}
+	}
+
+	if c.TargetFeederChurnRate() < 0 || c.TargetFeederMaxRequestsRate() <= 0 {
+		return fmt.Errorf("invalid target feeder churn/max requests rates: %f/%f", c.TargetFeederChurnRate(),
+			c.TargetFeederMaxRequestsRate())
+	}
+
+	if c.TargetFeederKp() < 0 || c.TargetFeederKi() < 0 {
+		return fmt.Errorf("the target feeder gains must be >= 0: Kp=%f Ki=%f", c.TargetFeederKp(), c.TargetFeederKi())
+	}
+
+	if c.usesFeeder("process") && c.ProcessFeederCommand() == "" {
+		return fmt.Errorf("the process feeder must have a command")
+	}
+
+	if c.ResourceGen() == "trace" {
+		trace := &c.ResourcesGenerator.Trace
+		if trace.FilePath == "" {
+			return fmt.Errorf("the trace resource generator must have a file")
+		}
+		if trace.CPUsColumn < 0 || trace.MemoryColumn < 0 {
+			return fmt.Errorf("invalid trace CPUs/memory columns: %d/%d", trace.CPUsColumn, trace.MemoryColumn)
+		}
+		if trace.CPUsScale <= 0 || trace.MemoryScale <= 0 {
+			return fmt.Errorf("the trace CPUs/memory scales must be > 0: %f/%f", trace.CPUsScale, trace.MemoryScale)
+		}
+		if trace.Assignment != "sequential" && trace.Assignment != "sample" {
+			return fmt.Errorf("invalid trace assignment: %s", trace.Assignment)
+		}
+	}
+
+	if c.ResourceGen() == "distribution" {
+		nodesDist := &c.ResourcesGenerator.Distribution
+		if err := nodesDist.CPUs.validate(); err != nil {
+			return fmt.Errorf("invalid nodes CPUs distribution: %s", err)
+		}
+		if err := nodesDist.Memory.validate(); err != nil {
+			return fmt.Errorf("invalid nodes memory distribution: %s", err)
+		}
+		if nodesDist.CPUClass < 0 {
+			return fmt.Errorf("invalid nodes CPU class: %d", nodesDist.CPUClass)
+		}
+		if nodesDist.Correlation < -1 || nodesDist.Correlation > 1 {
+			return fmt.Errorf("the nodes correlation must be in [-1, 1]: %f", nodesDist.Correlation)
+		}
+	}
+
+	for i := range c.CapacityChanges.Scheduled {
+		if err := c.CapacityChanges.Scheduled[i].validate(); err != nil {
+			return err
+		}
+	}
+	if c.CapacityChanges.RandomRate < 0 || c.CapacityChanges.RandomRate > 100 {
+		return fmt.Errorf("the random capacity changes rate must be in [0, 100]: %f", c.CapacityChanges.RandomRate)
+	}
+	if c.CapacityChanges.RandomResources != "cpus" && c.CapacityChanges.RandomResources != "memory" &&
+		c.CapacityChanges.RandomResources != "both" {
+		return fmt.Errorf("invalid random capacity changes resources: %s", c.CapacityChanges.RandomResources)
+	}
+	if c.CapacityChanges.RandomMinFactor < 0 || c.CapacityChanges.RandomMaxFactor < c.CapacityChanges.RandomMinFactor {
+		return fmt.Errorf("invalid random capacity changes factors: [%f, %f]", c.CapacityChanges.RandomMinFactor,
+			c.CapacityChanges.RandomMaxFactor)
+	}
+	if c.CapacityChanges.RandomDuration < 0 {
+		return fmt.Errorf("the random capacity changes duration must be >= 0: %d", c.CapacityChanges.RandomDuration)
+	}
+
+	containerEventsDists := []*Distribution{c.ContainerLifetime(), c.ContainerTimeToCrash(), c.ContainerTimeToOOM()}
+	for _, dist := range containerEventsDists {
+		if dist.IsSet() {
+			if err := dist.validate(); err != nil {
+				return fmt.Errorf("invalid container events distribution: %s", err)
+			}
+		}
+	}
+
+	if err := validateImages(c.Images.Catalogue); err != nil {
+		return err
+	}
+	if c.Images.CacheSize < 0 {
+		return fmt.Errorf("the image cache size must be >= 0: %d", c.Images.CacheSize)
+	}
+	if c.Images.PullBandwidth <= 0 || c.Images.PullLatency.Duration < 0 {
+		return fmt.Errorf("invalid image pull bandwidth/latency: %f, %s", c.Images.PullBandwidth, c.Images.PullLatency.Duration)
+	}
+
+	apiNodes := make(map[int]bool)
+	for _, nodeIndex := range c.API.Nodes {
+		if nodeIndex < 0 || nodeIndex >= c.NumberOfNodes || apiNodes[nodeIndex] {
+			return fmt.Errorf("invalid or repeated API node: %d", nodeIndex)
+		}
+		apiNodes[nodeIndex] = true
+	}
+	if c.API.BasePort <= 0 || c.API.BasePort+len(c.API.Nodes) > 65536 {
+		return fmt.Errorf("invalid API base port: %d", c.API.BasePort)
+	}
+	if c.API.TickDelay.Duration < 0 {
+		return fmt.Errorf("the API tick delay must be >= 0: %s", c.API.TickDelay.Duration)
+	}
+
+	if c.Overlay != "chord" && c.ChordMockChurn() {
+		return fmt.Errorf("churn is only supported by the chord overlay mock")
+	}
+	if c.KademliaMock.BucketSize <= 0 || c.KademliaMock.Alpha <= 0 {
+		return fmt.Errorf("invalid kademlia bucket size/alpha: %d, %d", c.KademliaMock.BucketSize, c.KademliaMock.Alpha)
+	}
+
+	switch c.Network.Coordinates {
+	case "none", "random", "clustered":
+	case "file":
+		if c.Network.FilePath == "" {
+			return fmt.Errorf("the network coordinates file path is missing")
+		}
+	default:
+		return fmt.Errorf("invalid network coordinates: %s", c.Network.Coordinates)
+	}
+	if c.Network.Dimensions <= 0 || c.Network.SpaceSize <= 0 {
+		return fmt.Errorf("invalid network dimensions/space size: %d, %f", c.Network.Dimensions, c.Network.SpaceSize)
+	}
+	if c.Network.Clusters <= 0 || c.Network.ClusterRadius < 0 {
+		return fmt.Errorf("invalid network clusters/cluster radius: %d, %f", c.Network.Clusters, c.Network.ClusterRadius)
+	}
+	if c.Network.ProximityCandidates < 0 {
+		return fmt.Errorf("the proximity candidates must be >= 0: %d", c.Network.ProximityCandidates)
+	}
+	if c.Network.ProximityCandidates > 0 && !c.HasNetworkCoordinates() {
+		return fmt.Errorf("the proximity neighbour selection needs the network coordinates")
+	}
+
+	switch c.MessageSizes.Model {
+	case "json", "binary", "gzip", "fixed":
+	default:
+		return fmt.Errorf("invalid message size model: %s", c.MessageSizes.Model)
+	}
+	if c.MessageSizes.CacheSamples < 0 {
+		return fmt.Errorf("the message size cache samples must be >= 0: %d", c.MessageSizes.CacheSamples)
+	}
+	if c.MessageSizes.DefaultSize <= 0 {
+		return fmt.Errorf("the message default size must be > 0: %d", c.MessageSizes.DefaultSize)
+	}
+	for msgType, size := range c.MessageSizes.FixedSizes {
+		if size <= 0 {
+			return fmt.Errorf("the message %s fixed size must be > 0: %d", msgType, size)
+		}
+	}
+
+	if c.ChordMock.GUIDPlacement != "uniform" && c.ChordMock.GUIDPlacement != "hashed" {
+		return fmt.Errorf("invalid chord GUID placement: %s", c.ChordMock.GUIDPlacement)
+	}
+	churn := &c.ChordMock.Churn
+	if churn.InitialNodes <= 0 || churn.InitialNodes > 100 {
+		return fmt.Errorf("the chord's initial nodes must be in ]0, 100]: %f", churn.InitialNodes)
+	}
+	if churn.JoinRate < 0 || churn.JoinRate > 100 || churn.LeaveRate < 0 || churn.LeaveRate > 100 {
+		return fmt.Errorf("the chord's join/leave rates must be in [0, 100]: %f, %f", churn.JoinRate, churn.LeaveRate)
+	}
+	if churn.Repair != "instant" && churn.Repair != "periodic" {
+		return fmt.Errorf("invalid chord repair: %s", churn.Repair)
+	}
+	if churn.StabilizeTicks <= 0 || churn.FixFingers < 0 {
+		return fmt.Errorf("invalid chord stabilize ticks/fix fingers: %d, %d", churn.StabilizeTicks, churn.FixFingers)
 	}
 
 	if !isValidLogLevel(c.CaravelaLogLevel) {
@@ -161,6 +694,51 @@ func (c *Configuration) validate() error {
 	return nil
 }
 
+// validateTenants validates a mix of tenants (an empty mix is valid).
+func validateTenants(tenants []Tenant) error {
+	if len(tenants) == 0 {
+		return nil
+	}
+
+	tenantsNames := make(map[string]bool)
+	tenantsPercentage := 0
+	for _, tenant := range tenants {
+		if tenant.Name == "" || tenantsNames[tenant.Name] {
+			return fmt.Errorf("the tenants must have an unique name: %s", tenant.Name)
+		}
+		tenantsNames[tenant.Name] = true
+		tenantsPercentage += tenant.Percentage
+	}
+	if tenantsPercentage != 100 {
+		return fmt.Errorf("the tenants percentage must sum 100%%: %d", tenantsPercentage)
+	}
+	return nil
+}
+
+// validateImages validates the catalogue of images (an empty catalogue is valid).
+func validateImages(images []Image) error {
+	if len(images) == 0 {
+		return nil
+	}
+
+	imagesNames := make(map[string]bool)
+	imagesPercentage := 0
+	for _, image := range images {
+		if image.Name == "" || imagesNames[image.Name] {
+			return fmt.Errorf("the images must have an unique name: %s", image.Name)
+		}
+		if image.Size <= 0 {
+			return fmt.Errorf("the image %s must have a size > 0: %d", image.Name, image.Size)
+		}
+		imagesNames[image.Name] = true
+		imagesPercentage += image.Percentage
+	}
+	if imagesPercentage != 100 {
+		return fmt.Errorf("the images percentage must sum 100%%: %d", imagesPercentage)
+	}
+	return nil
+}
+
 func (c *Configuration) TotalNumberOfNodes() int {
 	return c.NumberOfNodes
 }
@@ -203,8 +781,338 @@ func (c *Configuration) Feeder() string {
 	return c.RequestFeeder.RequestFeeder
 }
 
-func (c *Configuration) ChordMockSpeedupNodes() int {
-	return c.ChordMock.SpeedupNodes
+func (c *Configuration) Tenants() []Tenant {
+	res := make([]Tenant, len(c.RequestFeeder.Tenants))
+	copy(res, c.RequestFeeder.Tenants)
+	return res
+}
+
+func (c *Configuration) FeederVictimPolicy() string {
+	return c.RequestFeeder.VictimPolicy
+}
+
+// FeederSource returns the label used to tag the requests generated by the configured feeder.
+func (c *Configuration) FeederSource() string {
+	if c.RequestFeeder.Source == "" {
+		return c.Feeder()
+	}
+	return c.RequestFeeder.Source
+}
+
+func (c *Configuration) JsonFeederNormalization() string {
+	return c.RequestFeeder.Json.Normalization
+}
+
+func (c *Configuration) JsonFeederScaleFactor() float64 {
+	return c.RequestFeeder.Json.ScaleFactor
+}
+
+func (c *Configuration) JsonFeederCPUClassAssignment() string {
+	return c.RequestFeeder.Json.CPUClassAssignment
+}
+
+func (c *Configuration) JsonFeederCPUClass() int {
+	return c.RequestFeeder.Json.CPUClass
+}
+
+func (c *Configuration) JsonFeederCPUClassThresholds() []int {
+	res := make([]int, len(c.RequestFeeder.Json.CPUClassThresholds))
+	copy(res, c.RequestFeeder.Json.CPUClassThresholds)
+	return res
+}
+
+func (c *Configuration) TargetFeederUtilization() []float64 {
+	res := make([]float64, len(c.RequestFeeder.Target.TargetUtilization))
+	copy(res, c.RequestFeeder.Target.TargetUtilization)
+	return res
+}
+
+func (c *Configuration) TargetFeederChurnRate() float64 {
+	return c.RequestFeeder.Target.ChurnRate
+}
+
+func (c *Configuration) TargetFeederMaxRequestsRate() float64 {
+	return c.RequestFeeder.Target.MaxRequestsRate
+}
+
+func (c *Configuration) TargetFeederKp() float64 {
+	return c.RequestFeeder.Target.Kp
+}
+
+func (c *Configuration) TargetFeederKi() float64 {
+	return c.RequestFeeder.Target.Ki
+}
+
+func (c *Configuration) ProcessFeederCommand() string {
+	return c.RequestFeeder.Process.Command
+}
+
+func (c *Configuration) ProcessFeederArgs() []string {
+	res := make([]string, len(c.RequestFeeder.Process.Args))
+	copy(res, c.RequestFeeder.Process.Args)
+	return res
+}
+
+func (c *Configuration) NumFeederSources() int {
+	return len(c.RequestFeeder.Sources)
+}
+
+// FeederSourceConfiguration returns a copy of the configurations where the request feeder is replaced
+// by the index-th source of the mix feeder.
+func (c *Configuration) FeederSourceConfiguration(index int) *Configuration {
+	source := c.RequestFeeder.Sources[index]
+	res := *c
+	res.RequestFeeder.RequestFeeder = source.RequestFeeder
+	res.RequestFeeder.Source = source.Name
+	res.RequestFeeder.Sources = nil
+	if len(source.Tenants) > 0 {
+		res.RequestFeeder.Tenants = source.Tenants
+	}
+	if len(source.RequestsProfile) > 0 {
+		res.RequestFeeder.RequestsProfile = source.RequestsProfile
+	}
+	if len(source.DeployRequestsRate) > 0 {
+		res.RequestFeeder.DeployRequestsRate = source.DeployRequestsRate
+	}
+	if len(source.StopRequestsRate) > 0 {
+		res.RequestFeeder.StopRequestsRate = source.StopRequestsRate
+	}
+	return &res
+}
+
+func (c *Configuration) TraceGeneratorFilePath() string {
+	return c.ResourcesGenerator.Trace.FilePath
+}
+
+func (c *Configuration) TraceGeneratorHasHeader() bool {
+	return c.ResourcesGenerator.Trace.HasHeader
+}
+
+// TraceGeneratorColumns returns the indexes of the CPUs, memory, platform and filter columns.
+func (c *Configuration) TraceGeneratorColumns() (int, int, int, int) {
+	trace := &c.ResourcesGenerator.Trace
+	return trace.CPUsColumn, trace.MemoryColumn, trace.PlatformColumn, trace.FilterColumn
+}
+
+func (c *Configuration) TraceGeneratorFilterValue() string {
+	return c.ResourcesGenerator.Trace.FilterValue
+}
+
+// TraceGeneratorScales returns the factors that convert the trace's CPUs and memory into cores and MB.
+func (c *Configuration) TraceGeneratorScales() (float64, float64) {
+	return c.ResourcesGenerator.Trace.CPUsScale, c.ResourcesGenerator.Trace.MemoryScale
+}
+
+func (c *Configuration) TraceGeneratorAssignment() string {
+	return c.ResourcesGenerator.Trace.Assignment
+}
+
+// TraceGeneratorCPUClass returns the CPU class of the given CPU platform.
+func (c *Configuration) TraceGeneratorCPUClass(platform string) int {
+	if cpuClass, exist := c.ResourcesGenerator.Trace.PlatformsClass[platform]; exist {
+		return cpuClass
+	}
+	return c.ResourcesGenerator.Trace.DefaultCPUClass
+}
+
+func (c *Configuration) DistributionGeneratorCPUClass() int {
+	return c.ResourcesGenerator.Distribution.CPUClass
+}
+
+func (c *Configuration) DistributionGeneratorCPUs() *Distribution {
+	return &c.ResourcesGenerator.Distribution.CPUs
+}
+
+func (c *Configuration) DistributionGeneratorMemory() *Distribution {
+	return &c.ResourcesGenerator.Distribution.Memory
+}
+
+func (c *Configuration) DistributionGeneratorCorrelation() float64 {
+	return c.ResourcesGenerator.Distribution.Correlation
+}
+
+// ScheduledCapacityChanges returns the capacity changes scheduled for specific ticks.
+func (c *Configuration) ScheduledCapacityChanges() []CapacityChange {
+	return c.CapacityChanges.Scheduled
+}
+
+func (c *Configuration) RandomCapacityChangesRate() float64 {
+	return c.CapacityChanges.RandomRate
+}
+
+func (c *Configuration) RandomCapacityChangesResources() string {
+	return c.CapacityChanges.RandomResources
+}
+
+// RandomCapacityChangesFactors returns the minimum and maximum factors of the capacity after a random change.
+func (c *Configuration) RandomCapacityChangesFactors() (float64, float64) {
+	return c.CapacityChanges.RandomMinFactor, c.CapacityChanges.RandomMaxFactor
+}
+
+func (c *Configuration) RandomCapacityChangesDuration() int {
+	return c.CapacityChanges.RandomDuration
+}
+
+// HasCapacityChanges returns true if the nodes' capacity changes during the simulation.
+func (c *Configuration) HasCapacityChanges() bool {
+	return len(c.CapacityChanges.Scheduled) > 0 || c.CapacityChanges.RandomRate > 0
+}
+
+// ContainerLifetime returns the distribution of the time (in ticks) until a container finishes on its own.
+func (c *Configuration) ContainerLifetime() *Distribution {
+	return &c.ContainerEvents.Lifetime
+}
+
+// ContainerTimeToCrash returns the distribution of the time (in ticks) until a container crashes.
+func (c *Configuration) ContainerTimeToCrash() *Distribution {
+	return &c.ContainerEvents.TimeToCrash
+}
+
+// ContainerTimeToOOM returns the distribution of the time (in ticks) until a container is OOM-killed.
+func (c *Configuration) ContainerTimeToOOM() *Distribution {
+	return &c.ContainerEvents.TimeToOOM
+}
+
+// HasContainerEvents returns true if the containers can end on their own during the simulation.
+func (c *Configuration) HasContainerEvents() bool {
+	return c.ContainerLifetime().IsSet() || c.ContainerTimeToCrash().IsSet() || c.ContainerTimeToOOM().IsSet()
+}
+
+func (c *Configuration) ImagesCatalogue() []Image {
+	res := make([]Image, len(c.Images.Catalogue))
+	copy(res, c.Images.Catalogue)
+	return res
+}
+
+// HasImages returns true if the containers' images are simulated.
+func (c *Configuration) HasImages() bool {
+	return len(c.Images.Catalogue) > 0
+}
+
+func (c *Configuration) ImageCacheSize() int {
+	return c.Images.CacheSize
+}
+
+func (c *Configuration) ImagePullBandwidth() float64 {
+	return c.Images.PullBandwidth
+}
+
+func (c *Configuration) ImagePullLatency() time.Duration {
+	return c.Images.PullLatency.Duration
+}
+
+// APINodes returns the indexes of the nodes that serve the REST API.
+func (c *Configuration) APINodes() []int {
+	res := make([]int, len(c.API.Nodes))
+	copy(res, c.API.Nodes)
+	return res
+}
+
+// HasAPINodes returns true if any node serves the REST API.
+func (c *Configuration) HasAPINodes() bool {
+	return len(c.API.Nodes) > 0
+}
+
+// APIBasePort returns the port of the first node that serves the REST API.
+func (c *Configuration) APIBasePort() int {
+	return c.API.BasePort
+}
+
+func (c *Configuration) APITickDelay() time.Duration {
+	return c.API.TickDelay.Duration
+}
+
+func (c *Configuration) OverlayMock() string {
+	return c.Overlay
+}
+
+func (c *Configuration) KademliaMockBucketSize() int {
+	return c.KademliaMock.BucketSize
+}
+
+func (c *Configuration) KademliaMockAlpha() int {
+	return c.KademliaMock.Alpha
+}
+
+func (c *Configuration) NetworkCoordinates() string {
+	return c.Network.Coordinates
+}
+
+// HasNetworkCoordinates returns true if the nodes have network coordinates (latency between them).
+func (c *Configuration) HasNetworkCoordinates() bool {
+	return c.Network.Coordinates != "none"
+}
+
+func (c *Configuration) NetworkDimensions() int {
+	return c.Network.Dimensions
+}
+
+func (c *Configuration) NetworkSpaceSize() float64 {
+	return c.Network.SpaceSize
+}
+
+// NetworkClusters returns the number of clusters and their radius (standard deviation) of the clustered placement.
+func (c *Configuration) NetworkClusters() (int, float64) {
+	return c.Network.Clusters, c.Network.ClusterRadius
+}
+
+func (c *Configuration) NetworkFilePath() string {
+	return c.Network.FilePath
+}
+
+// ProximityCandidates returns the number of nodes considered when choosing each overlay's finger/contact by
+// latency (proximity neighbour selection), 0 if it is disabled.
+func (c *Configuration) ProximityCandidates() int {
+	return c.Network.ProximityCandidates
+}
+
+func (c *Configuration) MessageSizeModel() string {
+	return c.MessageSizes.Model
+}
+
+// MessageSizeCacheSamples returns the number of messages of each type measured before using the type's
+// average size, 0 if all the messages are measured.
+func (c *Configuration) MessageSizeCacheSamples() int {
+	return c.MessageSizes.CacheSamples
+}
+
+// MessageFixedSize returns the size (bytes) of the message type in the fixed size model.
+func (c *Configuration) MessageFixedSize(msgType string) int {
+	if size, exist := c.MessageSizes.FixedSizes[msgType]; exist {
+		return size
+	}
+	return c.MessageSizes.DefaultSize
+}
+
+func (c *Configuration) ChordMockGUIDPlacement() string {
+	return c.ChordMock.GUIDPlacement
+}
+
+// ChordMockChurn returns true if the nodes join or leave the chord's ring during the simulation.
+func (c *Configuration) ChordMockChurn() bool {
+	churn := &c.ChordMock.Churn
+	return churn.InitialNodes < 100 || churn.JoinRate > 0 || churn.LeaveRate > 0
+}
+
+func (c *Configuration) ChordChurnInitialNodes() float64 {
+	return c.ChordMock.Churn.InitialNodes
+}
+
+// ChordChurnRates returns the percentage of the nodes that join and leave the ring in each tick.
+func (c *Configuration) ChordChurnRates() (float64, float64) {
+	return c.ChordMock.Churn.JoinRate, c.ChordMock.Churn.LeaveRate
+}
+
+func (c *Configuration) ChordChurnRepair() string {
+	return c.ChordMock.Churn.Repair
+}
+
+func (c *Configuration) ChordChurnStabilizeTicks() int {
+	return c.ChordMock.Churn.StabilizeTicks
+}
+
+func (c *Configuration) ChordChurnFixFingers() int {
+	return c.ChordMock.Churn.FixFingers
 }
 
 func (c *Configuration) ResourceGen() string {
@@ -219,6 +1127,10 @@ func (c *Configuration) OutputDirectoryPath() string {
 	return c.OutDirectoryPath
 }
 
+func (c *Configuration) LookupPathsSamplingInterval() int {
+	return c.LookupPathsSampling
+}
+
 func (c *Configuration) SimulatorLogsLevel() string {
 	return c.SimulatorLogLevel
 }
@@ -227,6 +1139,19 @@ func (c *Configuration) CaravelaLogsLevel() string {
 	return c.CaravelaLogLevel
 }
 
+// usesFeeder returns true if the given feeder is used directly or as a source of the mix feeder.
+func (c *Configuration) usesFeeder(feeder string) bool {
+	if c.Feeder() == feeder {
+		return true
+	}
+	for _, source := range c.RequestFeeder.Sources {
+		if c.Feeder() == "mix" && source.RequestFeeder == feeder {
+			return true
+		}
+	}
+	return false
+}
+
 // Print/log the current configurations in order to debug the programs behavior.
 func (c *Configuration) Print() {
 	util.Log.Infof("##################################################################")
@@ -240,6 +1165,11 @@ func (c *Configuration) Print() {
 	util.Log.Infof("Discovery Backends:       %v", c.CaravelaDiscoveryBackends())
 	util.Log.Infof("Request Feeder:           %s", c.Feeder())
 	util.Log.Infof("Output directory:         %s", c.OutputDirectoryPath())
+	util.Log.Infof("Lookup Paths Sampling:    %d", c.LookupPathsSamplingInterval())
+	util.Log.Infof("Message Size Model:       %s", c.MessageSizeModel())
+	if c.MessageSizeCacheSamples() > 0 {
+		util.Log.Infof("Message Size Cache:       %d samples", c.MessageSizeCacheSamples())
+	}
 	util.Log.Infof("Sim's log level:          %s", c.SimulatorLogsLevel())
 	util.Log.Infof("CARAVELA's log level:     %s", c.CaravelaLogsLevel())
 
@@ -249,19 +1179,130 @@ func (c *Configuration) Print() {
 	util.Log.Infof("  Request Feeder:         %s", c.Feeder())
 	util.Log.Infof("  Deploy Requests Rate:   %v", c.DeployRequestsRate())
 	util.Log.Infof("  Stop Requests Rate:     %v", c.StopRequestsRate())
+	util.Log.Infof("  Victim Policy:          %s", c.FeederVictimPolicy())
+	for _, tenant := range c.Tenants() {
+		util.Log.Infof("    Tenant %s:         %d%%", tenant.Name, tenant.Percentage)
+	}
 	for _, reqProfile := range c.RequestsProfile() {
-		util.Log.Infof("    <<%d;%d>;%d>:         %d%%", reqProfile.CPUClass, reqProfile.CPUs, reqProfile.Memory, reqProfile.Percentage)
+		util.Log.Infof("    <<%d;%s>;%s>:         %d%%", reqProfile.CPUClass, reqProfile.cpusString(), reqProfile.memoryString(), reqProfile.Percentage)
+	}
+	if c.usesFeeder("json") {
+		util.Log.Infof("  Json Normalization:     %s", c.JsonFeederNormalization())
+		util.Log.Infof("  Json Scale Factor:      %.2f", c.JsonFeederScaleFactor())
+		util.Log.Infof("  Json CPU Class:         %s", c.JsonFeederCPUClassAssignment())
+		util.Log.Infof("  Json Class Thresholds:  %v", c.JsonFeederCPUClassThresholds())
+	}
+	if c.usesFeeder("target") {
+		util.Log.Infof("  Target Utilization:     %v", c.TargetFeederUtilization())
+		util.Log.Infof("  Target Churn Rate:      %.2f", c.TargetFeederChurnRate())
+		util.Log.Infof("  Target Max Rate:        %.2f", c.TargetFeederMaxRequestsRate())
+		util.Log.Infof("  Target Kp/Ki:           %.3f/%.3f", c.TargetFeederKp(), c.TargetFeederKi())
+	}
+	if c.usesFeeder("process") {
+		util.Log.Infof("  Process Command:        %s %v", c.ProcessFeederCommand(), c.ProcessFeederArgs())
+	}
+	for i := 0; i < c.NumFeederSources(); i++ {
+		sourceConfigs := c.FeederSourceConfiguration(i)
+		util.Log.Infof("  Source %s:", sourceConfigs.FeederSource())
+		util.Log.Infof("    Request Feeder:       %s", sourceConfigs.Feeder())
+		util.Log.Infof("    Deploy Requests Rate: %v", sourceConfigs.DeployRequestsRate())
+		util.Log.Infof("    Stop Requests Rate:   %v", sourceConfigs.StopRequestsRate())
 	}
 	util.Log.Infof("")
 
 	util.Log.Infof("Resource Generation")
 	util.Log.Infof("  Resource Generator:     %s", c.ResourceGen())
 	util.Log.Infof("  Static Gen ResRequested:   <<%d;%d>;%d>", c.StaticGeneratorResources().CPUClass, c.StaticGeneratorResources().CPUs, c.StaticGeneratorResources().Memory)
+	if c.ResourceGen() == "trace" {
+		util.Log.Infof("  Trace File:             %s", c.TraceGeneratorFilePath())
+		util.Log.Infof("  Trace Assignment:       %s", c.TraceGeneratorAssignment())
+		util.Log.Infof("  Trace Platforms Class:  %v", c.ResourcesGenerator.Trace.PlatformsClass)
+	}
+	if c.ResourceGen() == "distribution" {
+		util.Log.Infof("  Nodes CPU Class:        %d", c.DistributionGeneratorCPUClass())
+		util.Log.Infof("  Nodes CPUs:             %s", c.DistributionGeneratorCPUs())
+		util.Log.Infof("  Nodes Memory:           %s", c.DistributionGeneratorMemory())
+		util.Log.Infof("  Nodes Correlation:      %.2f", c.DistributionGeneratorCorrelation())
+	}
 
 	util.Log.Infof("")
 
+	if c.HasCapacityChanges() {
+		minFactor, maxFactor := c.RandomCapacityChangesFactors()
+		util.Log.Infof("Capacity Changes")
+		util.Log.Infof("  Scheduled Changes:      %d", len(c.ScheduledCapacityChanges()))
+		util.Log.Infof("  Random Rate:            %.2f%%", c.RandomCapacityChangesRate())
+		util.Log.Infof("  Random Resources:       %s", c.RandomCapacityChangesResources())
+		util.Log.Infof("  Random Factors:         [%.2f, %.2f]", minFactor, maxFactor)
+		util.Log.Infof("  Random Duration:        %d ticks", c.RandomCapacityChangesDuration())
+		util.Log.Infof("")
+	}
+
+	if c.HasContainerEvents() {
+		util.Log.Infof("Container Events")
+		util.Log.Infof("  Lifetime:               %s", c.ContainerLifetime())
+		util.Log.Infof("  Time To Crash:          %s", c.ContainerTimeToCrash())
+		util.Log.Infof("  Time To OOM:            %s", c.ContainerTimeToOOM())
+		util.Log.Infof("")
+	}
+
+	if c.HasImages() {
+		util.Log.Infof("Images")
+		for _, image := range c.ImagesCatalogue() {
+			util.Log.Infof("  Image %-18s%d MB (%d%%)", image.Name+":", image.Size, image.Percentage)
+		}
+		util.Log.Infof("  Cache Size:             %d MB", c.ImageCacheSize())
+		util.Log.Infof("  Pull Bandwidth:         %.2f MB/s", c.ImagePullBandwidth())
+		util.Log.Infof("  Pull Latency:           %s", c.ImagePullLatency())
+		util.Log.Infof("")
+	}
+
+	if c.HasAPINodes() {
+		util.Log.Infof("API")
+		util.Log.Infof("  Nodes:                  %v", c.APINodes())
+		util.Log.Infof("  Base Port:              %d", c.APIBasePort())
+		util.Log.Infof("  Tick Delay:             %s", c.APITickDelay())
+		util.Log.Infof("")
+	}
+
+	if c.HasNetworkCoordinates() {
+		util.Log.Infof("Network")
+		util.Log.Infof("  Coordinates:            %s", c.NetworkCoordinates())
+		switch c.NetworkCoordinates() {
+		case "file":
+			util.Log.Infof("  Coordinates File:       %s", c.NetworkFilePath())
+		case "clustered":
+			clusters, clusterRadius := c.NetworkClusters()
+			util.Log.Infof("  Clusters:               %d (radius %.2f ms)", clusters, clusterRadius)
+			fallthrough
+		default:
+			util.Log.Infof("  Dimensions:             %d", c.NetworkDimensions())
+			util.Log.Infof("  Space Size:             %.2f ms", c.NetworkSpaceSize())
+		}
+		util.Log.Infof("  Proximity Candidates:   %d", c.ProximityCandidates())
+		util.Log.Infof("")
+	}
+
+	if c.OverlayMock() == "kademlia" {
+		util.Log.Infof("Kademlia Mock")
+		util.Log.Infof("  Bucket Size:            %d", c.KademliaMockBucketSize())
+		util.Log.Infof("  Alpha:                  %d", c.KademliaMockAlpha())
+		util.Log.Infof("##################################################################")
+		return
+	}
+
 	util.Log.Infof("Chord Mock")
-	util.Log.Infof("  Chord Mock Speedup:     %d", c.ChordMockSpeedupNodes())
+	util.Log.Infof("  GUID Placement:         %s", c.ChordMockGUIDPlacement())
+	if c.ChordMockChurn() {
+		joinRate, leaveRate := c.ChordChurnRates()
+		util.Log.Infof("  Churn Initial Nodes:    %.2f%%", c.ChordChurnInitialNodes())
+		util.Log.Infof("  Churn Join/Leave Rate:  %.2f%%/%.2f%%", joinRate, leaveRate)
+		util.Log.Infof("  Churn Repair:           %s", c.ChordChurnRepair())
+		if c.ChordChurnRepair() == "periodic" {
+			util.Log.Infof("  Stabilize Ticks:        %d", c.ChordChurnStabilizeTicks())
+			util.Log.Infof("  Fix Fingers:            %d", c.ChordChurnFixFingers())
+		}
+	}
 
 	util.Log.Infof("##################################################################")
 }
@@ -0,0 +1,146 @@
+package configuration
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Distribution describes a continuous probability distribution used to generate resources and durations.
+type Distribution struct {
+	Type    string    // uniform, normal, lognormal, pareto, exponential or empirical.
+	Min     float64   // Minimum value (for all the types except uniform and pareto the samples are only bounded when Max > Min).
+	Max     float64   // Maximum value.
+	Mean    float64   // Mean of the normal and exponential, or of the logarithm of the lognormal.
+	StdDev  float64   // Standard deviation of the normal, or of the logarithm of the lognormal.
+	Shape   float64   // Shape (tail index) of the pareto, whose scale is the Min (it needs a Max).
+	Bins    []float64 // Edges of the empirical histogram's bins (one more than the weights).
+	Weights []float64 // Weight of each of the empirical histogram's bins.
+}
+
+// IsSet returns true if the distribution was configured.
+func (d *Distribution) IsSet() bool {
+	return d.Type != ""
+}
+
+// validate verifies if the distribution's parameters are valid.
+func (d *Distribution) validate() error {
+	switch d.Type {
+	case "uniform":
+		if d.Max < d.Min {
+			return fmt.Errorf("uniform distribution with max < min: [%f, %f]", d.Min, d.Max)
+		}
+	case "normal", "lognormal":
+		if d.StdDev < 0 {
+			return fmt.Errorf("%s distribution with a negative standard deviation: %f", d.Type, d.StdDev)
+		}
+	case "pareto":
+		if d.Min <= 0 || d.Shape <= 0 {
+			return fmt.Errorf("pareto distribution must have min (scale) > 0 and shape > 0: %f, %f", d.Min, d.Shape)
+		}
+		if d.Max <= d.Min { // The heavy tail of an unbounded pareto overflows the resources.
+			return fmt.Errorf("pareto distribution must have max > min: [%f, %f]", d.Min, d.Max)
+		}
+	case "exponential":
+		if d.Mean <= 0 {
+			return fmt.Errorf("exponential distribution must have mean > 0: %f", d.Mean)
+		}
+	case "empirical":
+		if len(d.Weights) == 0 || len(d.Bins) != len(d.Weights)+1 {
+			return fmt.Errorf("empirical distribution must have one more bin edge than weights: %v %v", d.Bins, d.Weights)
+		}
+		weightsSum := float64(0)
+		for i, weight := range d.Weights {
+			if weight < 0 || d.Bins[i+1] < d.Bins[i] {
+				return fmt.Errorf("empirical distribution with negative weights or unordered bins: %v %v", d.Bins, d.Weights)
+			}
+			weightsSum += weight
+		}
+		if weightsSum == 0 {
+			return fmt.Errorf("empirical distribution without weights")
+		}
+	default:
+		return fmt.Errorf("invalid distribution type: %s", d.Type)
+	}
+	return nil
+}
+
+// Quantile returns the value for the given cumulative probability (inverse of the CDF).
+func (d *Distribution) Quantile(probability float64) float64 {
+	const epsilon = 1e-9
+	probability = math.Min(math.Max(probability, epsilon), 1-epsilon)
+
+	var res float64
+	switch d.Type {
+	case "uniform":
+		return d.Min + probability*(d.Max-d.Min)
+	case "normal":
+		res = d.Mean + d.StdDev*math.Sqrt2*math.Erfinv(2*probability-1)
+	case "lognormal":
+		res = math.Exp(d.Mean + d.StdDev*math.Sqrt2*math.Erfinv(2*probability-1))
+	case "pareto":
+		res = d.Min / math.Pow(1-probability, 1/d.Shape)
+	case "exponential":
+		res = -d.Mean * math.Log(1-probability)
+	case "empirical":
+		weightsSum := float64(0)
+		for _, weight := range d.Weights {
+			weightsSum += weight
+		}
+		target, acc := probability*weightsSum, float64(0)
+		res = d.Bins[len(d.Bins)-1]
+		for i, weight := range d.Weights {
+			if weight > 0 && acc+weight >= target { // Linear interpolation inside the bin.
+				res = d.Bins[i] + (target-acc)/weight*(d.Bins[i+1]-d.Bins[i])
+				break
+			}
+			acc += weight
+		}
+	}
+
+	if d.Max > d.Min {
+		res = math.Min(math.Max(res, d.Min), d.Max)
+	}
+	return res
+}
+
+// ExpectedValue returns the (numerically approximated) expected value of the distribution.
+func (d *Distribution) ExpectedValue() float64 {
+	const numSamples = 1000
+	acc := float64(0)
+	for i := 0; i < numSamples; i++ {
+		acc += d.Quantile((float64(i) + 0.5) / numSamples)
+	}
+	return acc / numSamples
+}
+
+func (d *Distribution) String() string {
+	switch d.Type {
+	case "uniform":
+		return fmt.Sprintf("uniform[%g,%g]", d.Min, d.Max)
+	case "normal", "lognormal":
+		return fmt.Sprintf("%s(%g,%g)", d.Type, d.Mean, d.StdDev)
+	case "pareto":
+		return fmt.Sprintf("pareto(%g,%g)", d.Min, d.Shape)
+	case "exponential":
+		return fmt.Sprintf("exponential(%g)", d.Mean)
+	case "empirical":
+		return fmt.Sprintf("empirical(%d bins)", len(d.Weights))
+	case "":
+		return "none"
+	}
+	return d.Type
+}
+
+// CorrelatedProbabilities returns two cumulative probabilities with the given correlation (Gaussian copula),
+// to be used in the quantiles of two distributions.
+func CorrelatedProbabilities(randomGenerator *rand.Rand, correlation float64) (float64, float64) {
+	firstNormal := randomGenerator.NormFloat64()
+	secondNormal := correlation*firstNormal + math.Sqrt(1-correlation*correlation)*randomGenerator.NormFloat64()
+	return normalCDF(firstNormal), normalCDF(secondNormal)
+}
+
+// normalCDF returns the cumulative distribution function of the standard normal distribution.
+func normalCDF(value float64) float64 {
+	return 0.5 * (1 + math.Erf(value/math.Sqrt2))
+}
@@ -0,0 +1,8 @@
+package configuration
+
+// Image represents a container's image of the catalogue and the percentage of the requests that use it.
+type Image struct {
+	Name       string
+	Size       int // Size of the image (in MB).
+	Percentage int
+}
@@ -1,9 +1,51 @@
 package configuration
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // TODO
 type RequestProfile struct {
-	CPUClass   int
-	CPUs       int
-	Memory     int
-	Percentage int
+	CPUClass             int
+	CPUs                 int // CPUs in CPU slices (CARAVELA's CPU granularity).
+	Memory               int // Memory in MB.
+	Percentage           int
+	CPUCoresDistribution Distribution // When set the CPUs are sampled (in cores) from it instead of using CPUs.
+	MemoryDistribution   Distribution // When set the memory (in MB) is sampled from it instead of using Memory.
+	Correlation          float64      // Correlation between the sampled CPUs and memory (Gaussian copula).
+}
+
+// validate verifies if the request profile's distributions are valid.
+func (r *RequestProfile) validate() error {
+	if r.CPUCoresDistribution.IsSet() {
+		if err := r.CPUCoresDistribution.validate(); err != nil {
+			return fmt.Errorf("invalid request profile CPU cores distribution: %s", err)
+		}
+	}
+	if r.MemoryDistribution.IsSet() {
+		if err := r.MemoryDistribution.validate(); err != nil {
+			return fmt.Errorf("invalid request profile memory distribution: %s", err)
+		}
+	}
+	if r.Correlation < -1 || r.Correlation > 1 {
+		return fmt.Errorf("the request profile correlation must be in [-1, 1]: %f", r.Correlation)
+	}
+	return nil
+}
+
+// cpusString returns a printable description of the profile's CPUs.
+func (r *RequestProfile) cpusString() string {
+	if r.CPUCoresDistribution.IsSet() {
+		return r.CPUCoresDistribution.String()
+	}
+	return strconv.Itoa(r.CPUs)
+}
+
+// memoryString returns a printable description of the profile's memory.
+func (r *RequestProfile) memoryString() string {
+	if r.MemoryDistribution.IsSet() {
+		return r.MemoryDistribution.String()
+	}
+	return strconv.Itoa(r.Memory)
 }
@@ -0,0 +1,7 @@
+package configuration
+
+// Tenant represents a tenant (user) of the system and the percentage of the requests it submits.
+type Tenant struct {
+	Name       string
+	Percentage int
+}
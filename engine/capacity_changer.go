@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela/api/types"
+	"log"
+	"math"
+	"math/rand"
+)
+
+// backendsReserveWithOffers holds the discovery backends whose nodes can reserve the reclaimed resources, and
+// if the resources are obtained through one of the node's offers (true) or from the node's free resources, with
+// the offer's ID ignored (false). The offering backends only give the resources of an existing offer.
+var backendsReserveWithOffers = map[string]bool{
+	"chord-single-offer":           true,
+	"chord-multiple-offer":         true,
+	"chord-multiple-offer-updates": true,
+	"chord-random":                 false,
+	"swarm":                        false,
+}
+
+// capacityChange represents a change of a node's capacity, relative to its original capacity.
+type capacityChange struct {
+	nodeIndex    int
+	cpusFactor   float64
+	memoryFactor float64
+}
+
+// capacityChanger decides, in each tick, the scheduled and random changes of the nodes' capacity.
+type capacityChanger struct {
+	randomGenerator  *rand.Rand                   // Pseudo-random generator used to choose the nodes affected.
+	originalCapacity []types.Resources            // Original capacity of each node (in Caravela's units).
+	currentCapacity  []types.Resources            // Current capacity of each node (in Caravela's units).
+	pending          []bool                       // True if the node didn't reserve all the resources reclaimed yet.
+	reserveWithOffer bool                         // True if the nodes reserve the resources through their offers.
+	restores         map[int][]int                // Tick<->Nodes whose original capacity is restored.
+	simConfigs       *configuration.Configuration // Simulator's configurations.
+}
+
+// newCapacityChanger creates a new capacity changer for nodes, of the given discovery backend, with the given
+// original capacities.
+func newCapacityChanger(simConfigs *configuration.Configuration, discoveryBackend string,
+	originalCapacity []types.Resources, rngSeed int64) *capacityChanger {
+	reserveWithOffer, exist := backendsReserveWithOffers[discoveryBackend]
+	if !exist && simConfigs.HasCapacityChanges() {
+		log.Panic(fmt.Errorf("the nodes of the %s discovery backend can't reserve the capacity reclaimed",
+			discoveryBackend))
+	}
+	return &capacityChanger{
+		randomGenerator:  rand.New(rand.NewSource(rngSeed)),
+		originalCapacity: originalCapacity,
+		currentCapacity:  append([]types.Resources(nil), originalCapacity...),
+		pending:          make([]bool, len(originalCapacity)),
+		reserveWithOffer: reserveWithOffer,
+		restores:         make(map[int][]int),
+		simConfigs:       simConfigs,
+	}
+}
+
+// changes returns the capacity changes that happen in the given tick.
+func (c *capacityChanger) changes(tick int) []capacityChange {
+	res := make([]capacityChange, 0)
+	nodesChanges := make(map[int]int) // Node's index<->Index of the node's change (the last change prevails).
+	addChange := func(change capacityChange) {
+		if changeIndex, exist := nodesChanges[change.nodeIndex]; exist {
+			res[changeIndex] = change
+			return
+		}
+		nodesChanges[change.nodeIndex] = len(res)
+		res = append(res, change)
+	}
+	numNodes := len(c.originalCapacity)
+
+	for _, nodeIndex := range c.restores[tick] {
+		addChange(capacityChange{nodeIndex: nodeIndex, cpusFactor: 1, memoryFactor: 1})
+	}
+	delete(c.restores, tick)
+
+	for _, scheduled := range c.simConfigs.ScheduledCapacityChanges() {
+		if scheduled.Tick != tick {
+			continue
+		}
+		numNodesChanged := int(math.Round(float64(numNodes) * scheduled.NodesPercentage / 100))
+		for _, nodeIndex := range c.randomGenerator.Perm(numNodes)[:numNodesChanged] {
+			addChange(capacityChange{nodeIndex: nodeIndex, cpusFactor: scheduled.CPUsFactor, memoryFactor: scheduled.MemoryFactor})
+			c.scheduleRestore(nodeIndex, tick, scheduled.Duration)
+		}
+	}
+
+	minFactor, maxFactor := c.simConfigs.RandomCapacityChangesFactors()
+	numRandomChanges := int(math.Round(float64(numNodes) * c.simConfigs.RandomCapacityChangesRate() / 100))
+	for i := 0; i < numRandomChanges; i++ {
+		change := capacityChange{nodeIndex: c.randomGenerator.Intn(numNodes), cpusFactor: 1, memoryFactor: 1}
+		factor := minFactor + c.randomGenerator.Float64()*(maxFactor-minFactor)
+		switch c.simConfigs.RandomCapacityChangesResources() {
+		case "cpus":
+			change.cpusFactor = factor
+		case "memory":
+			change.memoryFactor = factor
+		default:
+			change.cpusFactor, change.memoryFactor = factor, factor
+		}
+		addChange(change)
+		c.scheduleRestore(change.nodeIndex, tick, c.simConfigs.RandomCapacityChangesDuration())
+	}
+
+	return res
+}
+
+// capacity returns the node's capacity after the change, that becomes the node's current capacity.
+func (c *capacityChanger) capacity(change capacityChange) types.Resources {
+	original := c.originalCapacity[change.nodeIndex]
+	c.currentCapacity[change.nodeIndex] = types.Resources{
+		CPUClass: original.CPUClass,
+		CPUs:     int(math.Floor(float64(original.CPUs) * change.cpusFactor)),
+		Memory:   int(math.Floor(float64(original.Memory) * change.memoryFactor)),
+	}
+	return c.currentCapacity[change.nodeIndex]
+}
+
+// reclaimed returns the resources of the node's original capacity that its owner currently reclaims.
+func (c *capacityChanger) reclaimed(nodeIndex int) types.Resources {
+	res := types.Resources{
+		CPUs:   c.originalCapacity[nodeIndex].CPUs - c.currentCapacity[nodeIndex].CPUs,
+		Memory: c.originalCapacity[nodeIndex].Memory - c.currentCapacity[nodeIndex].Memory,
+	}
+	if res.CPUs < 0 { // The capacity above the original one isn't offered.
+		res.CPUs = 0
+	}
+	if res.Memory < 0 {
+		res.Memory = 0
+	}
+	return res
+}
+
+// pendingNodes returns the nodes that didn't reserve all the resources reclaimed by their owners yet.
+func (c *capacityChanger) pendingNodes() []int {
+	res := make([]int, 0)
+	for nodeIndex, pending := range c.pending {
+		if pending {
+			res = append(res, nodeIndex)
+		}
+	}
+	return res
+}
+
+// scheduleRestore schedules the restore of the node's original capacity (a duration of 0 is permanent).
+func (c *capacityChanger) scheduleRestore(nodeIndex, tick, duration int) {
+	if duration > 0 {
+		c.restores[tick+duration] = append(c.restores[tick+duration], nodeIndex)
+	}
+}
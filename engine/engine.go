@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"github.com/ivpusic/grpool"
 	"github.com/pkg/errors"
@@ -9,11 +10,15 @@ import (
 	"github.com/strabox/caravela-sim/engine/metrics"
 	"github.com/strabox/caravela-sim/mocks/caravela"
 	"github.com/strabox/caravela-sim/mocks/docker"
-	chordMock "github.com/strabox/caravela-sim/mocks/overlay/chord"
+	"github.com/strabox/caravela-sim/mocks/overlay"
+	"github.com/strabox/caravela-sim/mocks/wire"
 	"github.com/strabox/caravela-sim/util"
+	caravelaAPI "github.com/strabox/caravela/api"
 	"github.com/strabox/caravela/api/types"
 	caravelaConfig "github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/docker/events"
 	caravelaNode "github.com/strabox/caravela/node"
+	"math"
 	"runtime"
 	"time"
 )
@@ -23,6 +28,9 @@ const engineLogTag = "ENGINE"
 
 const numOfRandomBagsOfNode = 2
 
+// apiCallsChanSize is the number of REST API calls that can be queued between two ticks without blocking.
+const apiCallsChanSize = 256
+
 // Engine represents an instance of a Caravela's simulator engine.
 // It holds all the structures to control, feed and analyse a engine during a simulation.
 type Engine struct {
@@ -31,10 +39,17 @@ type Engine struct {
 	baseRngSeed    int64 // Base RNG seed for generating the node's max resources and the requests resources.
 
 	// Engine's main components.
-	nodes       []*caravelaNode.Node // Array with all the Caravela's nodes for the simulation.
-	overlayMock *chordMock.Mock      // Overlay that "connects" all nodes.
-	feeder      feeder.Feeder        // Used to feed the simulator with requests.
-	nodesBags   [][]*caravelaNode.Node
+	nodes        []*caravelaNode.Node   // Array with all the Caravela's nodes for the simulation.
+	dockerMocks  []*docker.ClientMock   // Docker engine of each node.
+	dockerEvents []<-chan *events.Event // Events channel of each node's docker engine.
+	overlayMock  overlay.Mock           // Overlay that "connects" all nodes.
+	feeder       feeder.Feeder          // Used to feed the simulator with requests.
+	nodesBags    [][]*caravelaNode.Node
+	capacities   *capacityChanger      // Changes the nodes' capacity during the simulation.
+	apiServers   []*caravela.APIServer // Servers of the nodes that serve the REST API.
+	apiCalls     chan func()           // Calls of the REST API, run in the ticks like the feeder's requests.
+
+	caravelaClientMock *caravela.RemoteClientMock // Delivers the messages between the nodes.
 
 	metricsCollector *metrics.Collector            // Metric's collector.
 	workersPool      *grpool.Pool                  // Pool of Goroutines to run the simulation.
@@ -61,6 +76,7 @@ func (e *Engine) Init(reuseEngine, lastSimulation bool, caravelaConfigurations *
 	}
 	e.workersPool = grpool.NewPool(maxWorkers, maxWorkers*30)
 	e.nodes = make([]*caravelaNode.Node, e.simulatorConfigs.TotalNumberOfNodes())
+	e.dockerMocks = make([]*docker.ClientMock, e.simulatorConfigs.TotalNumberOfNodes())
 	e.nodesBags = make([][]*caravelaNode.Node, numOfRandomBagsOfNode)
 	e.caravelaConfigs = caravelaConfigurations
 	e.feeder = feeder.Create(e.simulatorConfigs, caravelaConfigurations, e.baseRngSeed)
@@ -71,31 +87,45 @@ func (e *Engine) Init(reuseEngine, lastSimulation bool, caravelaConfigurations *
 
 	// External node's component mocks (Creation and initialization).
 	apiServerMock := caravela.NewAPIServerMock()
-	dockerClientMock := docker.NewClientMock(docker.CreateResourceGen(e.simulatorConfigs, e.caravelaConfigs, e.baseRngSeed))
-	caravelaClientMock := caravela.NewRemoteClientMock(e, e.metricsCollector)
-	if !e.isInit || !reuseEngine {
-		chordMock.Init(e.caravelaConfigs.ChordHashSizeBits())
-		e.overlayMock = chordMock.NewChordMock(e.simulatorConfigs.TotalNumberOfNodes(),
-			e.caravelaConfigs.ChordNumSuccessors(), e.simulatorConfigs.ChordMockSpeedupNodes(), e.metricsCollector)
-		e.overlayMock.Init()
+	resourcesGenerator := docker.CreateResourceGen(e.simulatorConfigs, e.caravelaConfigs, e.baseRngSeed)
+	containerEvents := docker.NewContainerEvents(e.simulatorConfigs, e.baseRngSeed)
+	imageRegistry := docker.NewImageRegistry(e.simulatorConfigs)
+	e.caravelaClientMock = caravela.NewRemoteClientMock(e, wire.CreateSizeModel(e.simulatorConfigs), e.metricsCollector)
+	apiPorts := make(map[int]int)
+	for i, nodeIndex := range e.simulatorConfigs.APINodes() {
+		apiPorts[nodeIndex] = e.simulatorConfigs.APIBasePort() + i
+	}
+	e.apiServers = make([]*caravela.APIServer, 0, len(apiPorts))
+	e.apiCalls = make(chan func(), apiCallsChanSize)
+	if !e.isInit || !reuseEngine || e.simulatorConfigs.ChordMockChurn() { // The ring changes with churn.
+		e.overlayMock = overlay.CreateOverlayMock(e.simulatorConfigs, e.caravelaConfigs, e.baseRngSeed, e.metricsCollector)
 	}
 
 	// Create the CARAVELA's nodes for the engine.
 	util.Log.Info(util.LogTag(engineLogTag) + "Initializing nodes...")
 	for i := 0; i < e.simulatorConfigs.NumberOfNodes; i++ {
 		tempIndex := i
+		var apiServer caravelaAPI.Server = apiServerMock
+		if port, exist := apiPorts[tempIndex]; exist { // The node serves the REST API.
+			nodeAPIServer := caravela.NewAPIServer(tempIndex, port, e.apiCalls, e.metricsCollector)
+			e.apiServers = append(e.apiServers, nodeAPIServer)
+			apiServer = nodeAPIServer
+		}
 		e.workersPool.WaitCount(1)
 		e.workersPool.JobQueue <- func() {
 			defer e.workersPool.JobDone()
 
-			overlayNodeMock := e.overlayMock.GetNodeMockByIndex(tempIndex)
-			nodeConfig, err := caravelaConfig.ObtainExternal(overlayNodeMock.IP(), e.caravelaConfigs)
+			nodeConfig, err := caravelaConfig.ObtainExternal(e.overlayMock.NodeIP(tempIndex), e.caravelaConfigs)
 			if err != nil {
 				panic(fmt.Errorf("can't make caravela configurations, error: %s", err))
 			}
 
-			e.nodes[tempIndex] = caravelaNode.NewNode(nodeConfig, e.overlayMock, caravelaClientMock, dockerClientMock, apiServerMock)
-			e.nodes[tempIndex].AddTrader(overlayNodeMock.Bytes())
+			e.dockerMocks[tempIndex] = docker.NewClientMock(tempIndex, resourcesGenerator, containerEvents, imageRegistry,
+				e.metricsCollector)
+			e.nodes[tempIndex] = caravelaNode.NewNode(nodeConfig, e.overlayMock, e.caravelaClientMock, e.dockerMocks[tempIndex], apiServer)
+			for _, traderGUID := range e.overlayMock.NodeTradersGUIDs(tempIndex) {
+				e.nodes[tempIndex].AddTrader(traderGUID)
+			}
 		}
 	}
 	e.workersPool.WaitAll()
@@ -108,11 +138,21 @@ func (e *Engine) Init(reuseEngine, lastSimulation bool, caravelaConfigurations *
 		e.workersPool.JobQueue <- func() {
 			defer e.workersPool.JobDone()
 
-			e.nodes[tempIndex].Start(true, util.RandomIP())
+			if err := e.nodes[tempIndex].Start(true, util.RandomIP()); err != nil {
+				panic(fmt.Errorf("can't start node %d, error: %s", tempIndex, err))
+			}
 		}
 	}
 	e.workersPool.WaitAll()
 
+	// Caravela's nodes only listen to the docker events outside of the simulation, the engine delivers them.
+	e.dockerEvents = make([]<-chan *events.Event, e.simulatorConfigs.TotalNumberOfNodes())
+	if e.simulatorConfigs.HasContainerEvents() {
+		for i, dockerMock := range e.dockerMocks {
+			e.dockerEvents[i] = dockerMock.Start()
+		}
+	}
+
 	// Initialize metric's collector.
 	maxNodesResources := make([]types.Resources, e.simulatorConfigs.TotalNumberOfNodes())
 	for i := range maxNodesResources {
@@ -127,9 +167,17 @@ func (e *Engine) Init(reuseEngine, lastSimulation bool, caravelaConfigurations *
 	}
 	e.workersPool.WaitAll()
 	e.metricsCollector.InitNewSimulation(e.caravelaConfigs.DiscoveryBackend(), maxNodesResources)
+	e.metricsCollector.SetNodesArcLength(e.overlayMock.ArcLengths())
+	e.capacities = newCapacityChanger(e.simulatorConfigs, e.caravelaConfigs.DiscoveryBackend(), maxNodesResources,
+		e.baseRngSeed)
 
 	// Initialize request feeder.
-	systemTotalCPUs, systemTotalMemory := dockerClientMock.MaxResourcesAvailable()
+	systemTotalCPUs, systemTotalMemory := 0, 0
+	for _, dockerMock := range e.dockerMocks {
+		nodeMaxCPUs, nodeMaxMemory := dockerMock.MaxResourcesAvailable()
+		systemTotalCPUs += nodeMaxCPUs
+		systemTotalMemory += nodeMaxMemory
+	}
 	e.feeder.Init(e.metricsCollector, types.Resources{CPUs: systemTotalCPUs, Memory: systemTotalMemory})
 	util.Log.Debugf(util.LogTag(engineLogTag)+"System Total ResRequested: <%d;%d>", systemTotalCPUs, systemTotalMemory)
 
@@ -182,10 +230,24 @@ func (e *Engine) Start() {
 		// 2nd. Do the actions dependent on time (e.g. actions fired by timers).
 		simLastTimeRefreshes, simLastTimeSpread = e.fireTimerActions(simCurrentTime, simLastTimeRefreshes, simLastTimeSpread)
 
-		// 3rd. Update metrics with system's current information.
+		// 3rd. Nodes joining and leaving the overlay, and its repair.
+		e.overlayMock.Tick(numTicks)
+
+		// 4th. Change the capacity of the nodes (e.g. owners reclaiming resources).
+		e.changeCapacities(numTicks)
+
+		// 5th. Containers ending on their own (e.g. finished or crashed) in the nodes' docker engines.
+		e.endContainers(numTicks)
+
+		// 6th. Update metrics with system's current information.
 		e.updateMetrics()
 
-		// 4th. Update the engine time using the tick mechanism.
+		// 7th. Give time to the users of the nodes' REST API.
+		if e.simulatorConfigs.HasAPINodes() {
+			time.Sleep(e.simulatorConfigs.APITickDelay())
+		}
+
+		// 8th. Update the engine time using the tick mechanism.
 		simCurrentTime = simCurrentTime + e.simulatorConfigs.TicksInterval()
 		numTicks++
 		if numTicks == e.simulatorConfigs.MaximumTicks() {
@@ -206,11 +268,22 @@ func (e *Engine) Start() {
 	e.release()
 }
 
-// acceptRequests receives requests from the feeder to be injected in the simulated caravela.
+// acceptRequests receives requests from the feeder to be injected in the simulated caravela, and the calls
+// received by the nodes' REST API since the previous tick.
 func (e *Engine) acceptRequests(ticksChan chan<- chan feeder.RequestTask, currentTime time.Duration) {
 	const requestChanSize = 30
 	defer e.workersPool.WaitAll()
 
+	runRequestTask := func(requestTask feeder.RequestTask) {
+		e.workersPool.WaitCount(1)
+		e.workersPool.JobQueue <- func() {
+			defer e.workersPool.JobDone()
+
+			nodeIndex, node := e.selectInjectedNode()
+			requestTask(nodeIndex, node, currentTime)
+		}
+	}
+
 	newTickChan := make(chan feeder.RequestTask, requestChanSize)
 	ticksChan <- newTickChan
 
@@ -218,14 +291,13 @@ func (e *Engine) acceptRequests(ticksChan chan<- chan feeder.RequestTask, curren
 		select {
 		case requestTask, more := <-newTickChan:
 			if more {
-				e.workersPool.WaitCount(1)
-				e.workersPool.JobQueue <- func() {
-					defer e.workersPool.JobDone()
-
-					nodeIndex, node := e.selectInjectedNode()
-					requestTask(nodeIndex, node, currentTime)
-				}
+				runRequestTask(requestTask)
 			} else {
+				// The API calls already target the node that received them.
+				for apiCalls := len(e.apiCalls); apiCalls > 0; apiCalls-- {
+					apiCall := <-e.apiCalls
+					runRequestTask(func(_ int, _ *caravelaNode.Node, _ time.Duration) { apiCall() })
+				}
 				return
 			}
 		}
@@ -283,6 +355,138 @@ func (e *Engine) fireTimerActions(currentTime time.Duration, lastTimeRefreshes,
 	return lastTimeRefreshes, lastTimeSpreadOffers
 }
 
+// changeCapacities applies the capacity changes of the tick to the nodes' docker engines, evicting the
+// containers that no longer fit in the new capacity. The nodes reserve the resources reclaimed by their owners,
+// so they stop offering them.
+func (e *Engine) changeCapacities(tick int) {
+	if !e.simulatorConfigs.HasCapacityChanges() {
+		return
+	}
+	defer e.workersPool.WaitAll()
+
+	changedNodes := make(map[int]bool)
+	for _, change := range e.capacities.changes(tick) {
+		tempChange := change
+		changedNodes[tempChange.nodeIndex] = true
+		e.workersPool.WaitCount(1)
+		e.workersPool.JobQueue <- func() {
+			defer e.workersPool.JobDone()
+
+			capacity := e.capacities.capacity(tempChange)
+			dockerMock, node := e.dockerMocks[tempChange.nodeIndex], e.nodes[tempChange.nodeIndex]
+			evicted := dockerMock.SetCapacity(capacity)
+			for _, containerID := range evicted {
+				if err := node.StopLocalContainer(context.Background(), containerID); err != nil {
+					dockerMock.RemoveContainer(containerID) // Not managed by the node, only the engine knows it.
+				}
+				e.feeder.ContainerEnded(containerID)
+			}
+			e.reserveCapacity(tempChange.nodeIndex)
+			e.metricsCollector.ContainersEvicted(int64(len(evicted)))
+			e.metricsCollector.CapacityChanged(tempChange.nodeIndex, capacity)
+			util.Log.Debugf(util.LogTag(engineLogTag)+"Node %d capacity: <%d;%d>, Evicted: %d",
+				tempChange.nodeIndex, capacity.CPUs, capacity.Memory, len(evicted))
+		}
+	}
+
+	// The nodes that couldn't reserve all the resources reclaimed (e.g. they had no offer) try it again.
+	for _, nodeIndex := range e.capacities.pendingNodes() {
+		if changedNodes[nodeIndex] {
+			continue
+		}
+		tempNodeIndex := nodeIndex
+		e.workersPool.WaitCount(1)
+		e.workersPool.JobQueue <- func() {
+			defer e.workersPool.JobDone()
+			e.reserveCapacity(tempNodeIndex)
+		}
+	}
+}
+
+// reserveCapacity makes the node hold the resources reclaimed by its owner in reservation containers, launched
+// through the node's offers like the users' containers, so the node's supplier stops offering them.
+// The previous reservations are released first, returning their resources to the supplier.
+// The node's supplier only exposes its resources' accounting to the containers launched and stopped in the node,
+// so the reservations go through the same path. The offering backends need one of the node's offers, tracked by
+// the remote client, and the offerless backends obtain the resources from the node's free resources (the offer's
+// ID isn't used), see backendsReserveWithOffers.
+func (e *Engine) reserveCapacity(nodeIndex int) {
+	dockerMock, node := e.dockerMocks[nodeIndex], e.nodes[nodeIndex]
+	remaining := e.capacities.reclaimed(nodeIndex)
+	reservationsIDs, reserved := dockerMock.Reservations()
+	if reserved.CPUs == remaining.CPUs && reserved.Memory == remaining.Memory {
+		e.capacities.pending[nodeIndex] = false
+		return
+	}
+	for _, reservationID := range reservationsIDs {
+		node.StopLocalContainer(context.Background(), reservationID)
+	}
+
+	nodeIP := e.overlayMock.NodeIP(nodeIndex)
+	for remaining.CPUs > 0 || remaining.Memory > 0 {
+		offerID, reservation := int64(0), remaining
+		if e.capacities.reserveWithOffer {
+			// An offer only provides the resources that it had when it was created.
+			id, offered, exist := e.caravelaClientMock.SupplierOffer(nodeIP)
+			if !exist {
+				break
+			}
+			offerID = id
+			reservation.CPUs = int(math.Min(float64(remaining.CPUs), float64(offered.CPUs)))
+			reservation.Memory = int(math.Min(float64(remaining.Memory), float64(offered.Memory)))
+		}
+		if reservation.CPUs == 0 && reservation.Memory == 0 {
+			break
+		}
+		_, err := node.LaunchContainers(context.Background(), &types.Node{IP: nodeIP, GUID: node.GUID()},
+			&types.Offer{ID: offerID, Amount: 1}, []types.ContainerConfig{{
+				Name:      docker.ReservationContainerName,
+				ImageKey:  docker.ReservationContainerName,
+				Resources: reservation,
+			}})
+		if err != nil {
+			break
+		}
+		remaining.CPUs -= reservation.CPUs
+		remaining.Memory -= reservation.Memory
+	}
+	e.capacities.pending[nodeIndex] = remaining.CPUs > 0 || remaining.Memory > 0
+}
+
+// endContainers advances the nodes' docker engines to the tick, delivering the died events of the containers
+// that ended on their own to the nodes, so they release the containers' resources as they do with the docker
+// events outside of the simulation.
+func (e *Engine) endContainers(tick int) {
+	if !e.simulatorConfigs.HasContainerEvents() {
+		return
+	}
+	defer e.workersPool.WaitAll()
+
+	for i := range e.dockerMocks {
+		tempIndex := i
+		e.workersPool.WaitCount(1)
+		e.workersPool.JobQueue <- func() {
+			defer e.workersPool.JobDone()
+
+			ended := e.dockerMocks[tempIndex].Tick(tick)
+			eventsChan, node := e.dockerEvents[tempIndex], e.nodes[tempIndex]
+		eventsLoop:
+			for {
+				select {
+				case event := <-eventsChan:
+					if event.Type == events.ContainerDied {
+						node.StopLocalContainer(context.Background(), event.Value)
+						e.feeder.ContainerEnded(event.Value)
+					}
+				default:
+					break eventsLoop
+				}
+			}
+			e.metricsCollector.ContainersEnded(ended[docker.Finished], ended[docker.Crashed], ended[docker.OOMKilled])
+		}
+	}
+}
+
 // updateMetrics updates all the collector's metrics.
 func (e *Engine) updateMetrics() {
 	defer e.workersPool.WaitAll()
@@ -297,6 +501,13 @@ func (e *Engine) updateMetrics() {
 
 			nodeFreeResources, nodeMaxResources, numActiveOffers, _ := tempNode.NodeInformationSim()
 			e.assertNodeState(nodeFreeResources, nodeMaxResources)
+			if e.simulatorConfigs.HasCapacityChanges() && e.capacities.pending[tempI] {
+				// The reclaimed resources that the node couldn't reserve yet aren't free.
+				_, reserved := e.dockerMocks[tempI].Reservations()
+				reclaimed := e.capacities.reclaimed(tempI)
+				nodeFreeResources.CPUs = int(math.Max(0, float64(nodeFreeResources.CPUs-(reclaimed.CPUs-reserved.CPUs))))
+				nodeFreeResources.Memory = int(math.Max(0, float64(nodeFreeResources.Memory-(reclaimed.Memory-reserved.Memory))))
+			}
 			e.metricsCollector.SetNodeState(tempI, nodeFreeResources, int64(numActiveOffers), int64(tempNode.DebugSizeBytes()))
 		}
 	}
@@ -319,8 +530,15 @@ func (e *Engine) selectInjectedNode() (int, *caravelaNode.Node) {
 func (e *Engine) release() {
 	util.Log.Info(util.LogTag(engineLogTag) + "Clearing engine objects...")
 	e.workersPool.Release()
+	for _, apiServer := range e.apiServers {
+		apiServer.Stop()
+	}
+	e.apiServers = nil
 	e.feeder = nil
 	e.nodes = nil
+	e.dockerMocks = nil
+	e.dockerEvents = nil
+	e.capacities = nil
 	e.workersPool = nil
 	if e.lastSimulation {
 		e.overlayMock = nil
@@ -331,16 +549,21 @@ func (e *Engine) release() {
 
 // NodeByIP returns the caravela node and index given the node's IP address.
 func (e *Engine) NodeByIP(ip string) (*caravelaNode.Node, int) {
-	index, _ := e.overlayMock.GetNodeMockByIP(ip)
+	index := e.overlayMock.NodeIndexByIP(ip)
 	return e.nodes[index], index
 }
 
 // NodeByGUID returns the caravela node and index given the node's GUID.
 func (e *Engine) NodeByGUID(guid string) (*caravelaNode.Node, int) {
-	index, _ := e.overlayMock.GetNodeMockByGUID(guid)
+	index := e.overlayMock.NodeIndexByGUID(guid)
 	return e.nodes[index], index
 }
 
+// ContainerPullTime returns the time spent pulling the image of the container running in the node.
+func (e *Engine) ContainerPullTime(nodeIndex int, containerID string) time.Duration {
+	return e.dockerMocks[nodeIndex].ContainerPullTime(containerID)
+}
+
 // randomNode returns a random node from the simulated active nodes.
 func (e *Engine) randomNode() (int, *caravelaNode.Node) {
 	randIndex := util.RandomInteger(0, len(e.nodes)-1)
@@ -12,4 +12,7 @@ type RequestTask func(randNodeIndex int, randNode *node.Node, currentTime time.D
 type Feeder interface {
 	Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources)
 	Start(ticksChannel <-chan chan RequestTask)
+	// ContainerEnded informs the feeder that a container ended without a stop request (e.g. it finished, it
+	// crashed or it was evicted). The containers not deployed by the feeder are ignored.
+	ContainerEnded(containerID string)
 }
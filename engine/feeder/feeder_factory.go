@@ -20,6 +20,9 @@ var feeders = make(map[string]Factory)
 func init() {
 	Register("random", newRandomFeeder)
 	Register("json", newJsonFeeder)
+	Register("mix", newMixFeeder)
+	Register("target", newTargetFeeder)
+	Register("process", newProcessFeeder)
 }
 
 // Register can be used to register a new request feeder in order to be available.
@@ -0,0 +1,42 @@
+package feeder
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/util"
+	"math/rand"
+)
+
+// imageMix chooses the image of the requests' containers following the catalogue's percentages.
+type imageMix struct {
+	names       []string // Images names.
+	percentages []int    // Cumulative percentage of each image.
+}
+
+// newImageMix creates a new image mix.
+func newImageMix(images []configuration.Image) *imageMix {
+	res := &imageMix{
+		names:       make([]string, len(images)),
+		percentages: make([]int, len(images)),
+	}
+	acc := 0
+	for i, image := range images {
+		acc += image.Percentage
+		res.names[i] = image.Name
+		res.percentages[i] = acc
+	}
+	return res
+}
+
+// Image returns an image drawn from the mix, or a random image name when there isn't a catalogue of images.
+func (m *imageMix) Image(randomGenerator *rand.Rand) string {
+	if len(m.names) == 0 {
+		return util.RandomName()
+	}
+	randImage := randomGenerator.Intn(100)
+	for i, percentage := range m.percentages {
+		if randImage < percentage {
+			return m.names[i]
+		}
+	}
+	return m.names[len(m.names)-1]
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/strabox/caravela/api/types"
 	caravelaConfigs "github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/node"
+	caravelaUtil "github.com/strabox/caravela/util"
 	"math"
+	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -20,41 +23,102 @@ import (
 
 const logJsonFeederTag = "JS-FEEDER"
 
+// jsonRequest represents a request read from the json trace.
+type jsonRequest struct {
+	Time            int64   `json:"Time"`
+	JobID           int64   `json:"job id"`
+	EventType       int     `json:"event type"`
+	User            string  `json:"user"`
+	CPUs            float64 `json:"CPU request"`
+	Memory          float64 `json:"memory request"`
+	Priority        int     `json:"priority"`
+	SchedulingClass int     `json:"scheduling class"`
+}
+
 // jsonFeeder generates a stream of user requests reading from a json file.
 type jsonFeeder struct {
 	collector              *metrics.Collector             // Metrics collector that collects system level metrics.
 	containerInjectionNode sync.Map                       // Map of ContainerID<->NodeIndex.
 	currentRequests        sync.Map                       // Map of RequestID<->ContainerID.
 	systemTotalResources   types.Resources                // Caravela's maximum resources.
+	randomGenerator        *rand.Rand                     // Pseudo-random generator.
+	tenants                *tenantMix                     // Assigns the requests without user to the tenants.
+	images                 *imageMix                      // Chooses the image of the requests' containers.
+	referenceResources     types.Resources                // Resources that the trace's resources are relative to.
+	cpuClassesPercentage   []int                          // Cumulative percentage of each CPU class.
+	requestsPerClass       map[int]int64                  // Number of deploy requests generated for each CPU class.
+	resourcesPerClass      map[int]types.Resources        // Resources requested for each CPU class.
 	simConfigs             *configuration.Configuration   // Simulator's configurations.
 	caravelaConfigs        *caravelaConfigs.Configuration // Caravela's configurations.
 }
 
 // newJsonFeeder creates a new json feeder.
-func newJsonFeeder(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration, _ int64) (Feeder, error) {
+func newJsonFeeder(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+	cpuClassesPercentage := make([]int, len(caravelaConfigs.ResourcesPartitions().CPUClasses))
+	cpuClassAcc := 0
+	for i, cpuClass := range caravelaConfigs.ResourcesPartitions().CPUClasses {
+		cpuClassAcc += cpuClass.Percentage
+		cpuClassesPercentage[i] = cpuClassAcc
+	}
+
 	return &jsonFeeder{
 		collector:              nil,
 		containerInjectionNode: sync.Map{},
 		currentRequests:        sync.Map{},
+		randomGenerator:        rand.New(caravelaUtil.NewSourceSafe(rand.NewSource(rngSeed))),
+		tenants:                newTenantMix(simConfigs.Tenants()),
+		images:                 newImageMix(simConfigs.ImagesCatalogue()),
+		referenceResources:     jsonReferenceResources(simConfigs, caravelaConfigs),
+		cpuClassesPercentage:   cpuClassesPercentage,
+		requestsPerClass:       make(map[int]int64),
+		resourcesPerClass:      make(map[int]types.Resources),
 		simConfigs:             simConfigs,
 		caravelaConfigs:        caravelaConfigs,
 	}, nil
 }
 
+// jsonReferenceResources returns the resources that the trace's resources are relative to, depending on the
+// configured normalization.
+func jsonReferenceResources(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration) types.Resources {
+	switch simConfigs.JsonFeederNormalization() {
+	case "absolute": // Trace's resources are in cores and MB.
+		return types.Resources{CPUs: caravelaConfigs.CPUSlices(), Memory: 1}
+	case "profile": // Trace's resources are relative to the largest request profile.
+		res := types.Resources{}
+		for _, profile := range simConfigs.RequestsProfile() {
+			profileCPUs, profileMemory := expectedProfileResources(&profile, caravelaConfigs.CPUSlices())
+			if int(profileCPUs) > res.CPUs {
+				res.CPUs = int(profileCPUs)
+			}
+			if int(profileMemory) > res.Memory {
+				res.Memory = int(profileMemory)
+			}
+		}
+		return res
+	default: // Trace's resources are relative to the largest node of the resources partitions (for any generator).
+		res := types.Resources{}
+		for _, cpuClass := range caravelaConfigs.ResourcesPartitions().CPUClasses {
+			for _, cpus := range cpuClass.CPUCores {
+				if cpus.Value > res.CPUs {
+					res.CPUs = cpus.Value
+				}
+				for _, memory := range cpus.Memory {
+					if memory.Value > res.Memory {
+						res.Memory = memory.Value
+					}
+				}
+			}
+		}
+		return res
+	}
+}
+
 func (j *jsonFeeder) Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources) {
 	j.collector = metricsCollector
 	j.systemTotalResources = systemTotalResources
 }
 
 func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
-	type Request struct {
-		Time      int64   `json:"Time"`
-		JobID     int64   `json:"job id"`
-		EventType int     `json:"event type"`
-		User      string  `json:"user"`
-		CPUs      float64 `json:"CPU request"`
-		Memory    float64 `json:"memory request"`
-	}
 	const maxJsonFiles = 20
 	jsonFileCounter := 0
 	tick := 0
@@ -70,16 +134,21 @@ func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
 
 				// Generate the requests from the json request stream.
 				for jsonRequestStream.More() && (j.ratioSystemResources(tickCpusAcc, tickMemoryAcc) < 0.05) {
-					var reqJson Request
+					var reqJson jsonRequest
 					err := jsonRequestStream.Decode(&reqJson)
 					if err != nil {
 						panic(err)
 					}
 
 					requestID := strconv.FormatInt(reqJson.JobID, 10)
-					reqResources := j.generateRequestResources(reqJson.CPUs, reqJson.Memory)
 
 					if reqJson.EventType == 1 && !j.requestExists(requestID) { // Deploy container request.
+						reqResources := j.generateRequestResources(&reqJson)
+						reqTenant := reqJson.User
+						if reqTenant == "" {
+							reqTenant = j.tenants.Tenant(j.randomGenerator)
+						}
+						reqImage := j.images.Image(j.randomGenerator)
 
 						tickCpusAcc += reqResources.CPUs
 						tickMemoryAcc += reqResources.Memory
@@ -88,11 +157,11 @@ func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
 						newTickChan <- func(nodeIndex int, injectedNode *node.Node, currentTime time.Duration) {
 							if _, exist := j.currentRequests.Load(requestID); !exist {
 								requestCtx := context.WithValue(context.Background(), types.RequestIDKey, requestID)
-								j.collector.CreateRunRequest(nodeIndex, requestID, reqResources)
+								j.collector.CreateRunRequest(nodeIndex, requestID, j.simConfigs.FeederSource(), reqTenant, reqResources)
 								contStatus, err := injectedNode.SubmitContainers(
 									requestCtx,
 									[]types.ContainerConfig{{
-										ImageKey:     util.RandomName(),
+										ImageKey:     reqImage,
 										Name:         util.RandomName(),
 										PortMappings: caravela.EmptyPortMappings(),
 										Args:         caravela.EmptyContainerArgs(),
@@ -119,7 +188,8 @@ func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
 						}
 
 						injNode, exist := j.containerInjectionNode.Load(containerID)
-						if !exist {
+						if !exist { // The container already ended.
+							j.currentRequests.Delete(requestID)
 							continue
 						}
 						injectionNode, ok := injNode.(*node.Node)
@@ -141,6 +211,7 @@ func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
 				}
 
 				if !jsonRequestStream.More() && (jsonFileCounter == maxJsonFiles) {
+					j.printRequestsMapping()
 					util.Log.Fatalf(util.LogTag(logJsonFeederTag) + "No more requests in the json files!!!")
 					currentJsonFile.Close() // Close the request file.
 					close(newTickChan)      // No more user requests for this tick.
@@ -154,13 +225,18 @@ func (j *jsonFeeder) Start(ticksChannel <-chan chan RequestTask) {
 				close(newTickChan) // No more user requests for this tick.
 			} else { // Simulator closed ticks channel.
 				currentJsonFile.Close() // Close the request file.
-				return                  // Stop feeding engine
+				j.printRequestsMapping()
+				return // Stop feeding engine
 			}
 		}
 		tick++
 	}
 }
 
+func (j *jsonFeeder) ContainerEnded(containerID string) {
+	j.containerInjectionNode.Delete(containerID)
+}
+
 func (j *jsonFeeder) getJsonRequestStream(filePath string) (*json.Decoder, *os.File) {
 	fileReader, err := os.Open(filePath)
 	if err != nil {
@@ -175,43 +251,79 @@ func (j *jsonFeeder) getJsonRequestStream(filePath string) (*json.Decoder, *os.F
 	return jsonRequestStream, fileReader
 }
 
-// generateRequestResources ...
-func (j *jsonFeeder) generateRequestResources(normalizedCpus, normalizedMemory float64) types.Resources {
-	cpuClasses := make([]int, len(j.caravelaConfigs.ResourcesPartitions().CPUClasses))
-	maxCpus := 0
-	maxMemory := 0
+// generateRequestResources translates the trace's request resources into CARAVELA's request resources.
+func (j *jsonFeeder) generateRequestResources(request *jsonRequest) types.Resources {
+	scaleFactor := j.simConfigs.JsonFeederScaleFactor()
+	cpuClass := j.requestCPUClass(request)
 
-	cpuClassAcc := 0
-	for i, cpuClass := range j.caravelaConfigs.ResourcesPartitions().CPUClasses {
-		cpuClasses[i] = cpuClass.Percentage + cpuClassAcc
-		cpuClassAcc += cpuClass.Percentage
-		for _, cpus := range cpuClass.CPUCores {
-			if cpus.Value > maxCpus {
-				maxCpus = cpus.Value
-			}
-			for _, memory := range cpus.Memory {
-				if memory.Value > maxMemory {
-					maxMemory = memory.Value
-				}
+	res := types.Resources{
+		CPUClass: types.CPUClass(cpuClass),
+		CPUs:     int(math.Ceil(scaleFactor * request.CPUs * float64(j.referenceResources.CPUs))),
+		Memory:   int(math.Ceil(scaleFactor * request.Memory * float64(j.referenceResources.Memory))),
+	}
+
+	classResources := j.resourcesPerClass[cpuClass]
+	classResources.CPUs += res.CPUs
+	classResources.Memory += res.Memory
+	j.resourcesPerClass[cpuClass] = classResources
+	j.requestsPerClass[cpuClass]++
+	return res
+}
+
+// requestCPUClass returns the CPU class of the request, depending on the configured CPU class assignment.
+func (j *jsonFeeder) requestCPUClass(request *jsonRequest) int {
+	switch j.simConfigs.JsonFeederCPUClassAssignment() {
+	case "ratio": // Following the CPU classes percentages of the resources partitions.
+		randInt := j.randomGenerator.Intn(101)
+		for i, cpuClassPercentage := range j.cpuClassesPercentage {
+			if randInt <= cpuClassPercentage {
+				return i
 			}
 		}
+		return 0
+	case "priority":
+		return j.traceFieldCPUClass(request.Priority)
+	case "scheduling-class":
+		return j.traceFieldCPUClass(request.SchedulingClass)
+	default:
+		return j.simConfigs.JsonFeederCPUClass()
 	}
+}
 
-	/*
-		chosenCpuClass := 0
-		randInt := j.randomGenerator.Intn(101)
-		for i, cpuClass := range cpuClasses {
-			if randInt <= cpuClass {
-				chosenCpuClass = i
-				break
+// traceFieldCPUClass maps the value of a trace's field into a CPU class. Without thresholds the value is used
+// directly as the class.
+func (j *jsonFeeder) traceFieldCPUClass(value int) int {
+	cpuClass := value
+	if thresholds := j.simConfigs.JsonFeederCPUClassThresholds(); len(thresholds) > 0 {
+		cpuClass = 0
+		for i, threshold := range thresholds {
+			if value >= threshold {
+				cpuClass = i
 			}
 		}
-	*/
+	}
 
-	return types.Resources{
-		CPUClass: 0,
-		CPUs:     int(math.Ceil((4 * normalizedCpus) * float64(maxCpus))),
-		Memory:   int(math.Ceil((4 * normalizedMemory) * float64(maxMemory))),
+	if cpuClass < 0 {
+		return 0
+	} else if maxCPUClass := len(j.cpuClassesPercentage) - 1; cpuClass > maxCPUClass && maxCPUClass >= 0 {
+		return maxCPUClass
+	}
+	return cpuClass
+}
+
+// printRequestsMapping logs a summary of how the trace's requests were mapped into CARAVELA's requests.
+func (j *jsonFeeder) printRequestsMapping() {
+	util.Log.Infof(util.LogTag(logJsonFeederTag)+"Requests mapping (normalization: %s, scale: %.2f, CPU class: %s)",
+		j.simConfigs.JsonFeederNormalization(), j.simConfigs.JsonFeederScaleFactor(), j.simConfigs.JsonFeederCPUClassAssignment())
+
+	cpuClasses := make([]int, 0, len(j.requestsPerClass))
+	for cpuClass := range j.requestsPerClass {
+		cpuClasses = append(cpuClasses, cpuClass)
+	}
+	sort.Ints(cpuClasses)
+	for _, cpuClass := range cpuClasses {
+		util.Log.Infof(util.LogTag(logJsonFeederTag)+"CPU Class %d: %d requests, <%d,%d> requested", cpuClass,
+			j.requestsPerClass[cpuClass], j.resourcesPerClass[cpuClass].CPUs, j.resourcesPerClass[cpuClass].Memory)
 	}
 }
 
@@ -0,0 +1,108 @@
+package feeder
+
+import (
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api/types"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	"sync"
+)
+
+const logMixFeederTag = "MIX-FEEDER"
+
+// sourceTickChanSize is the size of the channel used to receive the requests of a source in a tick.
+const sourceTickChanSize = 50
+
+// mixFeeder combines the requests streams of several feeders (workload sources) into a single stream.
+// In each tick the requests of every source are forwarded to the simulator, one source after the other.
+type mixFeeder struct {
+	sourcesNames []string                     // Names of the workload sources.
+	sources      []Feeder                     // Feeders that generate the requests of each source.
+	simConfigs   *configuration.Configuration // Simulator's configurations.
+}
+
+// newMixFeeder creates a new mix feeder.
+func newMixFeeder(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+	sourcesNames := make([]string, simConfigs.NumFeederSources())
+	sources := make([]Feeder, simConfigs.NumFeederSources())
+	for i := range sources {
+		sourceConfigs := simConfigs.FeederSourceConfiguration(i)
+
+		feederFactory, exist := feeders[sourceConfigs.Feeder()]
+		if !exist || sourceConfigs.Feeder() == "mix" {
+			return nil, fmt.Errorf("invalid %s request feeder for the source %s", sourceConfigs.Feeder(),
+				sourceConfigs.FeederSource())
+		}
+
+		// Each source has its own seed in order to keep the sources independent of each other.
+		source, err := feederFactory(sourceConfigs, caravelaConfigs, rngSeed+int64(i+1))
+		if err != nil {
+			return nil, err
+		}
+
+		sourcesNames[i] = sourceConfigs.FeederSource()
+		sources[i] = source
+	}
+
+	return &mixFeeder{
+		sourcesNames: sourcesNames,
+		sources:      sources,
+		simConfigs:   simConfigs,
+	}, nil
+}
+
+func (m *mixFeeder) Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources) {
+	for _, source := range m.sources {
+		source.Init(metricsCollector, systemTotalResources)
+	}
+}
+
+func (m *mixFeeder) ContainerEnded(containerID string) {
+	for _, source := range m.sources {
+		source.ContainerEnded(containerID)
+	}
+}
+
+func (m *mixFeeder) Start(ticksChannel <-chan chan RequestTask) {
+	sourcesWaitGroup := sync.WaitGroup{}
+	sourcesTicksChans := make([]chan chan RequestTask, len(m.sources))
+	for i, source := range m.sources {
+		sourcesTicksChans[i] = make(chan chan RequestTask)
+		sourcesWaitGroup.Add(1)
+		go func(source Feeder, sourceTicksChan <-chan chan RequestTask) {
+			defer sourcesWaitGroup.Done()
+			source.Start(sourceTicksChan)
+		}(source, sourcesTicksChans[i])
+	}
+
+	requestsPerSource := make([]int64, len(m.sources))
+	for {
+		select {
+		case newTickChan, more := <-ticksChannel:
+			if more {
+				for i := range m.sources {
+					sourceTickChan := make(chan RequestTask, sourceTickChanSize)
+					sourcesTicksChans[i] <- sourceTickChan
+					for requestTask := range sourceTickChan {
+						newTickChan <- requestTask
+						requestsPerSource[i]++
+					}
+				}
+
+				close(newTickChan) // No more user requests for this tick
+			} else { // Simulator closed ticks channel
+				for i := range sourcesTicksChans {
+					close(sourcesTicksChans[i])
+				}
+				sourcesWaitGroup.Wait()
+
+				for i, sourceName := range m.sourcesNames {
+					util.Log.Infof(util.LogTag(logMixFeederTag)+"Source %s: %d requests tasks", sourceName, requestsPerSource[i])
+				}
+				return // Stop feeding engine
+			}
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package feeder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/mocks/caravela"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api/types"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node"
+	"github.com/strabox/caravela/node/common/guid"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const logProcessFeederTag = "P-FEEDER"
+
+// processTickMessage is sent to the process, in each tick, with the current state of the system.
+type processTickMessage struct {
+	Type              string          `json:"type"`
+	Tick              int             `json:"tick"`
+	Time              float64         `json:"time"`              // Simulated time (in seconds).
+	Utilization       float64         `json:"utilization"`       // Ratio of the system's resources used.
+	RunningContainers int64           `json:"runningContainers"` // Containers, deployed by the process, running.
+	SystemResources   types.Resources `json:"systemResources"`   // System's total resources.
+	Deployed          []string        `json:"deployed"`          // Deploy requests that succeeded in the last tick.
+	Failed            []string        `json:"failed"`            // Deploy requests that failed in the last tick.
+}
+
+// processCommand is read from the process with a deploy/stop request or the end of the tick's requests.
+// A stop command refers to the ID of a deploy command that succeeded in a previous tick.
+type processCommand struct {
+	Type     string `json:"type"` // deploy, stop or done.
+	ID       string `json:"id"`   // Request's ID, used to stop the container deployed by it.
+	CPUClass int    `json:"cpuClass"`
+	CPUs     int    `json:"cpus"`
+	Memory   int    `json:"memory"`
+	Tenant   string `json:"tenant"`
+	Image    string `json:"image"` // Image of the container (drawn from the catalogue when it is empty).
+}
+
+// processFeeder generates a stream of user requests using an external process (e.g. a script) that is driven
+// over a line protocol: each tick is sent to the process stdin as a JSON line and the process answers with
+// deploy/stop commands as JSON lines in its stdout, ending the tick's requests with a done command.
+type processFeeder struct {
+	collector            *metrics.Collector           // Metrics collector that collects system level metrics.
+	systemTotalResources types.Resources              // Caravela's maximum resources.
+	containers           sync.Map                     // Map of RequestID<->*containerRunning.
+	containersRequests   sync.Map                     // Map of ContainerID<->RequestID.
+	runningContainers    int64                        // Number of containers, deployed by the process, running.
+	resultsMutex         sync.Mutex                   // Protects the deploy results of the tick.
+	deployed             []string                     // Deploy requests that succeeded in the tick.
+	failed               []string                     // Deploy requests that failed in the tick.
+	randomGenerator      *rand.Rand                   // Pseudo-random generator.
+	images               *imageMix                    // Chooses the image of the containers without one.
+	simConfigs           *configuration.Configuration // Simulator's configurations.
+}
+
+// newProcessFeeder creates a new process feeder.
+func newProcessFeeder(simConfigs *configuration.Configuration, _ *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+	if simConfigs.ProcessFeederCommand() == "" {
+		return nil, fmt.Errorf("the process feeder needs a command")
+	}
+
+	return &processFeeder{
+		collector:          nil,
+		containers:         sync.Map{},
+		containersRequests: sync.Map{},
+		runningContainers:  0,
+		resultsMutex:       sync.Mutex{},
+		deployed:           make([]string, 0),
+		failed:             make([]string, 0),
+		randomGenerator:    rand.New(rand.NewSource(rngSeed)),
+		images:             newImageMix(simConfigs.ImagesCatalogue()),
+		simConfigs:         simConfigs,
+	}, nil
+}
+
+func (p *processFeeder) Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources) {
+	p.collector = metricsCollector
+	p.systemTotalResources = systemTotalResources
+}
+
+func (p *processFeeder) Start(ticksChannel <-chan chan RequestTask) {
+	var tickEncoder *json.Encoder
+	var commandsScanner *bufio.Scanner
+	process, processStdin, processStdout, err := p.startProcess()
+	processAlive := err == nil
+	if processAlive {
+		tickEncoder = json.NewEncoder(processStdin)
+		commandsScanner = bufio.NewScanner(processStdout)
+	} else { // The ticks are still consumed, without requests, until the simulation ends.
+		util.Log.Errorf(util.LogTag(logProcessFeederTag)+"Feeding stopped, %s", err)
+	}
+
+	totalDeploys, totalStops := 0, 0
+	tick := 0
+	for {
+		select {
+		case newTickChan, more := <-ticksChannel: // Send all the requests for this tickChan
+			if more {
+				if processAlive {
+					processAlive = p.sendTick(tickEncoder, tick)
+				}
+
+				tickDeploys := make(map[string]bool) // The deploys of the previous ticks are already stored.
+				for processAlive && commandsScanner.Scan() {
+					var command processCommand
+					if err := json.Unmarshal(commandsScanner.Bytes(), &command); err != nil {
+						util.Log.Warnf(util.LogTag(logProcessFeederTag)+"Invalid command %q, error: %s", commandsScanner.Text(), err)
+						continue
+					}
+
+					if command.Type == "done" {
+						break
+					} else if command.Type == "deploy" {
+						if _, running := p.containers.Load(command.ID); running || tickDeploys[command.ID] {
+							util.Log.Warnf(util.LogTag(logProcessFeederTag)+"Deploy with a duplicated ID ignored: %s", command.ID)
+							continue
+						}
+						tickDeploys[command.ID] = true
+						totalDeploys++
+						newTickChan <- p.deployTask(command)
+					} else if command.Type == "stop" {
+						totalStops++
+						newTickChan <- p.stopTask(command.ID)
+					} else {
+						util.Log.Warnf(util.LogTag(logProcessFeederTag)+"Unknown command type: %s", command.Type)
+					}
+				}
+				if processAlive && commandsScanner.Err() != nil {
+					util.Log.Errorf(util.LogTag(logProcessFeederTag)+"Error reading the process commands: %s", commandsScanner.Err())
+				}
+
+				close(newTickChan) // No more user requests for this tick
+			} else { // Simulator closed ticks channel
+				if processAlive {
+					tickEncoder.Encode(&processTickMessage{Type: "end", Tick: tick})
+				}
+				if process != nil {
+					processStdin.Close()
+					go io.Copy(ioutil.Discard, processStdout) // Unblock the process if it is still writing.
+					if err := process.Wait(); err != nil {
+						util.Log.Errorf(util.LogTag(logProcessFeederTag)+"Process ended with error: %s", err)
+					}
+				}
+				util.Log.Infof(util.LogTag(logProcessFeederTag)+"Deploy Requests: %d, Stop Requests: %d", totalDeploys, totalStops)
+				return // Stop feeding engine
+			}
+		}
+		tick++
+	}
+}
+
+// startProcess starts the process with its stdin and stdout piped to the feeder. The process is nil if it
+// couldn't be started.
+func (p *processFeeder) startProcess() (*exec.Cmd, io.WriteCloser, io.ReadCloser, error) {
+	process := exec.Command(p.simConfigs.ProcessFeederCommand(), p.simConfigs.ProcessFeederArgs()...)
+	process.Stderr = os.Stderr
+	processStdin, err := process.StdinPipe()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("process feeder can't open the process stdin: %s", err)
+	}
+	processStdout, err := process.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("process feeder can't open the process stdout: %s", err)
+	}
+	if err := process.Start(); err != nil {
+		return nil, nil, nil, fmt.Errorf("process feeder can't start the process: %s", err)
+	}
+	return process, processStdin, processStdout, nil
+}
+
+// sendTick sends the tick message to the process. It returns false if the process can't receive it.
+func (p *processFeeder) sendTick(tickEncoder *json.Encoder, tick int) bool {
+	p.resultsMutex.Lock()
+	deployed, failed := p.deployed, p.failed
+	p.deployed, p.failed = make([]string, 0), make([]string, 0)
+	p.resultsMutex.Unlock()
+
+	err := tickEncoder.Encode(&processTickMessage{
+		Type:              "tick",
+		Tick:              tick,
+		Time:              (time.Duration(tick) * p.simConfigs.TicksInterval()).Seconds(),
+		Utilization:       p.collector.SystemUsedResourcesRatio(),
+		RunningContainers: atomic.LoadInt64(&p.runningContainers),
+		SystemResources:   p.systemTotalResources,
+		Deployed:          deployed,
+		Failed:            failed,
+	})
+	if err != nil {
+		util.Log.Errorf(util.LogTag(logProcessFeederTag)+"Process stopped receiving ticks: %s", err)
+		return false
+	}
+	return true
+}
+
+// deployTask returns a request task that deploys the container requested by the process.
+func (p *processFeeder) deployTask(command processCommand) RequestTask {
+	resources := types.Resources{
+		CPUClass: types.CPUClass(command.CPUClass),
+		CPUs:     command.CPUs,
+		Memory:   command.Memory,
+	}
+	image := command.Image
+	if image == "" {
+		image = p.images.Image(p.randomGenerator)
+	}
+
+	return func(nodeIndex int, injectedNode *node.Node, currentTime time.Duration) {
+		requestID := guid.NewGUIDRandom().String() // Generate a GUID for tracking the request inside Caravela.
+		requestCtx := context.WithValue(context.Background(), types.RequestIDKey, requestID)
+		p.collector.CreateRunRequest(nodeIndex, requestID, p.simConfigs.FeederSource(), command.Tenant, resources)
+		contStatus, err := injectedNode.SubmitContainers(
+			requestCtx,
+			[]types.ContainerConfig{{
+				ImageKey:     image,
+				Name:         util.RandomName(),
+				PortMappings: caravela.EmptyPortMappings(),
+				Args:         caravela.EmptyContainerArgs(),
+				Resources:    resources,
+				GroupPolicy:  types.SpreadGroupPolicy,
+			}})
+		if err == nil {
+			p.containers.Store(command.ID, &containerRunning{
+				containerID:  contStatus[0].ContainerID,
+				injectedNode: injectedNode,
+				supplierIP:   contStatus[0].SupplierIP,
+				resources:    resources,
+				startTime:    currentTime,
+			})
+			p.containersRequests.Store(contStatus[0].ContainerID, command.ID)
+			atomic.AddInt64(&p.runningContainers, 1)
+		}
+		p.collector.ArchiveRunRequest(requestID, err == nil)
+
+		p.resultsMutex.Lock()
+		defer p.resultsMutex.Unlock()
+		if err == nil {
+			p.deployed = append(p.deployed, command.ID)
+		} else {
+			p.failed = append(p.failed, command.ID)
+		}
+	}
+}
+
+// stopTask returns a request task that stops the container deployed by the given process request.
+func (p *processFeeder) stopTask(id string) RequestTask {
+	return func(_ int, _ *node.Node, _ time.Duration) {
+		// Only the one that removes the container (stop or end) decrements the running containers.
+		container, exist := p.containers.LoadAndDelete(id)
+		if !exist {
+			util.Log.Debugf(util.LogTag(logProcessFeederTag)+"Stop of unknown request: %s", id)
+			return
+		}
+		atomic.AddInt64(&p.runningContainers, -1)
+
+		containerToRemove := container.(*containerRunning)
+		p.containersRequests.Delete(containerToRemove.containerID)
+		containerToRemove.injectedNode.StopContainers(context.Background(), []string{containerToRemove.containerID})
+	}
+}
+
+func (p *processFeeder) ContainerEnded(containerID string) {
+	if id, exist := p.containersRequests.LoadAndDelete(containerID); exist {
+		if _, running := p.containers.LoadAndDelete(id); running {
+			atomic.AddInt64(&p.runningContainers, -1)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package feeder
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela/api/types"
+	"math"
+	"math/rand"
+)
+
+// sampleProfileResources returns the resources for a request of the given profile, sampling the CPUs and the memory
+// from the profile's distributions when they are configured.
+func sampleProfileResources(profile *configuration.RequestProfile, randomGenerator *rand.Rand, cpuSlices int) types.Resources {
+	res := types.Resources{
+		CPUClass: types.CPUClass(profile.CPUClass),
+		CPUs:     profile.CPUs,
+		Memory:   profile.Memory,
+	}
+	if !profile.CPUCoresDistribution.IsSet() && !profile.MemoryDistribution.IsSet() {
+		return res
+	}
+
+	cpusProbability, memoryProbability := configuration.CorrelatedProbabilities(randomGenerator, profile.Correlation)
+	if profile.CPUCoresDistribution.IsSet() {
+		res.CPUs = coresToSlices(profile.CPUCoresDistribution.Quantile(cpusProbability), cpuSlices)
+	}
+	if profile.MemoryDistribution.IsSet() {
+		res.Memory = int(math.Max(1, math.Round(profile.MemoryDistribution.Quantile(memoryProbability))))
+	}
+	return res
+}
+
+// expectedProfileResources returns the expected CPUs and memory of the requests of the given profile.
+func expectedProfileResources(profile *configuration.RequestProfile, cpuSlices int) (float64, float64) {
+	cpus, memory := float64(profile.CPUs), float64(profile.Memory)
+	if profile.CPUCoresDistribution.IsSet() {
+		cpus = profile.CPUCoresDistribution.ExpectedValue() * float64(cpuSlices)
+	}
+	if profile.MemoryDistribution.IsSet() {
+		memory = profile.MemoryDistribution.ExpectedValue()
+	}
+	return cpus, memory
+}
+
+// coresToSlices converts an amount of cores into CPU slices (CARAVELA's CPU granularity), with at least one slice.
+func coresToSlices(cores float64, cpuSlices int) int {
+	return int(math.Max(1, math.Round(cores*float64(cpuSlices))))
+}
@@ -16,77 +16,56 @@ import (
 	"math"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const logRandFeederTag = "R-FEEDER"
 
-type containerRunning struct {
-	containerID  string
-	injectedNode *node.Node
-}
-
-type containersPerProfile struct {
-	mutex             sync.Mutex
-	containersRunning []*containerRunning
-}
-
-func newContainerPerProfile() *containersPerProfile {
-	return &containersPerProfile{
-		mutex:             sync.Mutex{},
-		containersRunning: make([]*containerRunning, 0),
-	}
-}
-
-func (c *containersPerProfile) AddRequest(containerRunning *containerRunning) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.containersRunning = append(c.containersRunning, containerRunning)
-}
-
-func (c *containersPerProfile) RemoveRequest() (*containerRunning, error) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	if len(c.containersRunning) == 0 {
-		return nil, errors.New("no request running")
-	}
-
-	res := c.containersRunning[len(c.containersRunning)-1]
-	c.containersRunning = c.containersRunning[:len(c.containersRunning)-1]
-	return res, nil
-}
-
 // randomFeeder generates a stream of user requests using a pre-defined defined requests profile.
 type randomFeeder struct {
-	collector            *metrics.Collector // Metrics collector that collects system level metrics.
-	reqProfiles          map[int]*containersPerProfile
+	collector            *metrics.Collector           // Metrics collector that collects system level metrics.
+	tenants              *tenantMix                   // Assigns the requests to the tenants.
+	images               *imageMix                    // Chooses the image of the requests' containers.
+	victimPolicy         victimPolicy                 // Selects the containers stopped by the stop requests.
+	containers           sync.Map                     // Map of ContainerID<->*containerRunning, not stopped yet.
+	containersSeq        int64                        // Sequence number of the containers deployed.
+	resourcesReleased    metrics.Resources            // Resources released by the stop requests.
 	randomGenerator      *rand.Rand                   // Pseudo-random generator.
+	cpuSlices            int                          // CPU slices of each core.
 	systemTotalResources types.Resources              // Caravela's maximum resources.
 	simConfigs           *configuration.Configuration // Simulator's configurations.
 }
 
 // newRandomFeeder creates a new random feeder.
-func newRandomFeeder(simConfigs *configuration.Configuration, _ *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+func newRandomFeeder(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+	randomGenerator := rand.New(caravelaUtil.NewSourceSafe(rand.NewSource(rngSeed)))
+	victimPolicy, err := newVictimPolicy(simConfigs.FeederVictimPolicy(), randomGenerator)
+	if err != nil {
+		return nil, err
+	}
+
 	return &randomFeeder{
-		collector:       nil,
-		reqProfiles:     make(map[int]*containersPerProfile),
-		randomGenerator: rand.New(caravelaUtil.NewSourceSafe(rand.NewSource(rngSeed))),
-		simConfigs:      simConfigs,
+		collector:         nil,
+		tenants:           newTenantMix(simConfigs.Tenants()),
+		images:            newImageMix(simConfigs.ImagesCatalogue()),
+		victimPolicy:      victimPolicy,
+		containers:        sync.Map{},
+		containersSeq:     0,
+		resourcesReleased: metrics.Resources{CPUs: 0, Memory: 0},
+		randomGenerator:   randomGenerator,
+		cpuSlices:         caravelaConfigs.CPUSlices(),
+		simConfigs:        simConfigs,
 	}, nil
 }
 
 func (rf *randomFeeder) Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources) {
 	rf.collector = metricsCollector
 	rf.systemTotalResources = systemTotalResources
-	for i := range rf.simConfigs.RequestsProfile() {
-		rf.reqProfiles[i] = newContainerPerProfile()
-	}
-
 }
 
 func (rf *randomFeeder) Start(ticksChannel <-chan chan RequestTask) {
 	totalResourcesSubmitted := types.Resources{CPUs: 0, Memory: 0}
-	totalResourcesReleased := types.Resources{CPUs: 0, Memory: 0}
 	submitRequests := rf.simConfigs.DeployRequestsRate()
 	stopRequests := rf.simConfigs.StopRequestsRate()
 	for i := range submitRequests {
@@ -108,47 +87,20 @@ func (rf *randomFeeder) Start(ticksChannel <-chan chan RequestTask) {
 					totalResourcesSubmitted.CPUs += resources.CPUs
 					totalResourcesSubmitted.Memory += resources.Memory
 
-					newTickChan <- func(nodeIndex int, injectedNode *node.Node, currentTime time.Duration) {
-						requestID := guid.NewGUIDRandom().String() // Generate a GUID for tracking the request inside Caravela.
-						requestCtx := context.WithValue(context.Background(), types.RequestIDKey, requestID)
-						rf.collector.CreateRunRequest(nodeIndex, requestID, resources)
-						contStatus, err := injectedNode.SubmitContainers(
-							requestCtx,
-							[]types.ContainerConfig{{
-								ImageKey:     util.RandomName(),
-								Name:         util.RandomName(),
-								PortMappings: caravela.EmptyPortMappings(),
-								Args:         caravela.EmptyContainerArgs(),
-								Resources:    resources,
-								GroupPolicy:  types.SpreadGroupPolicy,
-							}})
-						if err == nil {
-							rf.reqProfiles[profile].AddRequest(&containerRunning{containerID: contStatus[0].ContainerID, injectedNode: injectedNode})
-						}
-						rf.collector.ArchiveRunRequest(requestID, err == nil)
-					}
-
+					newTickChan <- rf.deployTask(profile, resources, rf.tenants.Tenant(rf.randomGenerator),
+						rf.images.Image(rf.randomGenerator))
 				}
 
 				for s := 0; s < int(stopRequests[currentSuperTick]); s++ { // Stop Containers Requests
-					profile, resProfile := rf.generateResourcesProfile()
-
-					newTickChan <- func(_ int, _ *node.Node, _ time.Duration) {
-						containerToRemove, err := rf.reqProfiles[profile].RemoveRequest()
-						if err == nil {
-							err := containerToRemove.injectedNode.StopContainers(context.Background(), []string{containerToRemove.containerID})
-							if err == nil {
-								totalResourcesReleased.CPUs += resProfile.CPUs
-								totalResourcesReleased.Memory += resProfile.Memory
-							}
-						}
-					}
+					profile, _ := rf.generateResourcesProfile()
+
+					newTickChan <- rf.stopTask(profile)
 				}
 
 				close(newTickChan) // No more user requests for this tick
 			} else { // Simulator closed ticks channel
 				util.Log.Infof(util.LogTag(logRandFeederTag)+"Total ResRequested Submitted: <%d,%d>", totalResourcesSubmitted.CPUs, totalResourcesSubmitted.Memory)
-				util.Log.Infof(util.LogTag(logRandFeederTag)+"Total ResRequested Released:  <%d,%d>", totalResourcesReleased.CPUs, totalResourcesReleased.Memory)
+				util.Log.Infof(util.LogTag(logRandFeederTag)+"Total ResRequested Released:  <%d,%d>", rf.resourcesReleased.CPUs, rf.resourcesReleased.Memory)
 				return // Stop feeding engine
 			}
 		}
@@ -159,7 +111,62 @@ func (rf *randomFeeder) Start(ticksChannel <-chan chan RequestTask) {
 	}
 }
 
-// TODO
+// deployTask returns a request task that deploys a container of the image with the given resources on behalf of
+// the tenant.
+func (rf *randomFeeder) deployTask(profile int, resources types.Resources, tenant, image string) RequestTask {
+	return func(nodeIndex int, injectedNode *node.Node, currentTime time.Duration) {
+		requestID := guid.NewGUIDRandom().String() // Generate a GUID for tracking the request inside Caravela.
+		requestCtx := context.WithValue(context.Background(), types.RequestIDKey, requestID)
+		rf.collector.CreateRunRequest(nodeIndex, requestID, rf.simConfigs.FeederSource(), tenant, resources)
+		contStatus, err := injectedNode.SubmitContainers(
+			requestCtx,
+			[]types.ContainerConfig{{
+				ImageKey:     image,
+				Name:         util.RandomName(),
+				PortMappings: caravela.EmptyPortMappings(),
+				Args:         caravela.EmptyContainerArgs(),
+				Resources:    resources,
+				GroupPolicy:  types.SpreadGroupPolicy,
+			}})
+		if err == nil {
+			container := &containerRunning{
+				containerID:  contStatus[0].ContainerID,
+				injectedNode: injectedNode,
+				supplierIP:   contStatus[0].SupplierIP,
+				profile:      profile,
+				resources:    resources,
+				startTime:    currentTime,
+				seq:          atomic.AddInt64(&rf.containersSeq, 1),
+			}
+			rf.containers.Store(container.containerID, container)
+			rf.victimPolicy.Add(container)
+		}
+		rf.collector.ArchiveRunRequest(requestID, err == nil)
+	}
+}
+
+// stopTask returns a request task that stops a running container chosen by the victim policy.
+func (rf *randomFeeder) stopTask(profile int) RequestTask {
+	return func(_ int, _ *node.Node, _ time.Duration) {
+		containerToRemove, err := rf.victimPolicy.Remove(profile)
+		if err == nil {
+			rf.containers.Delete(containerToRemove.containerID)
+			err := containerToRemove.injectedNode.StopContainers(context.Background(), []string{containerToRemove.containerID})
+			if err == nil {
+				atomic.AddInt64(&rf.resourcesReleased.CPUs, int64(containerToRemove.resources.CPUs))
+				atomic.AddInt64(&rf.resourcesReleased.Memory, int64(containerToRemove.resources.Memory))
+			}
+		}
+	}
+}
+
+func (rf *randomFeeder) ContainerEnded(containerID string) {
+	if container, exist := rf.containers.LoadAndDelete(containerID); exist {
+		rf.victimPolicy.Ended(container.(*containerRunning))
+	}
+}
+
+// generateResourcesProfile chooses a request profile and generates the resources for a request of it.
 func (rf *randomFeeder) generateResourcesProfile() (int, types.Resources) {
 	requestProfiles := rf.simConfigs.RequestsProfile()
 
@@ -176,11 +183,7 @@ func (rf *randomFeeder) generateResourcesProfile() (int, types.Resources) {
 	randProfile := rf.randomGenerator.Intn(101)
 	for i, profile := range requestProfiles {
 		if randProfile <= profile.Percentage {
-			return i, types.Resources{
-				CPUClass: types.CPUClass(profile.CPUClass),
-				CPUs:     profile.CPUs,
-				Memory:   profile.Memory,
-			}
+			return i, sampleProfileResources(&requestProfiles[i], rf.randomGenerator, rf.cpuSlices)
 		}
 	}
 	panic(fmt.Errorf("random feeder problem generating resources, rand profile: %d", randProfile))
@@ -0,0 +1,128 @@
+package feeder
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api/types"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	"math"
+)
+
+const logTargetFeederTag = "T-FEEDER"
+
+// targetFeeder drives the system's utilization into a target (or a sequence of targets) using a PI controller
+// fed, in every tick, with the utilization measured by the metrics collector.
+type targetFeeder struct {
+	*randomFeeder           // Generates the requests resources and the deploy/stop tasks.
+	avgRequestRatio float64 // Average ratio of the system's resources requested by a deploy request.
+}
+
+// newTargetFeeder creates a new target feeder.
+func newTargetFeeder(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration, rngSeed int64) (Feeder, error) {
+	randFeeder, err := newRandomFeeder(simConfigs, caravelaConfigs, rngSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &targetFeeder{
+		randomFeeder:    randFeeder.(*randomFeeder),
+		avgRequestRatio: 0,
+	}, nil
+}
+
+func (t *targetFeeder) Init(metricsCollector *metrics.Collector, systemTotalResources types.Resources) {
+	t.randomFeeder.Init(metricsCollector, systemTotalResources)
+
+	avgCPUs, avgMemory := float64(0), float64(0)
+	for _, profile := range t.simConfigs.RequestsProfile() {
+		profileCPUs, profileMemory := expectedProfileResources(&profile, t.cpuSlices)
+		avgCPUs += profileCPUs * float64(profile.Percentage) / 100
+		avgMemory += profileMemory * float64(profile.Percentage) / 100
+	}
+	if systemTotalResources.CPUs > 0 && systemTotalResources.Memory > 0 {
+		t.avgRequestRatio = (avgCPUs/float64(systemTotalResources.CPUs) + avgMemory/float64(systemTotalResources.Memory)) / 2
+	}
+}
+
+func (t *targetFeeder) Start(ticksChannel <-chan chan RequestTask) {
+	targets := t.simConfigs.TargetFeederUtilization()
+	superTicksSize := int(math.Ceil(float64(t.simConfigs.MaximumTicks()) / float64(len(targets))))
+	churnRequests := int(float64(t.simConfigs.TotalNumberOfNodes()) * t.simConfigs.TargetFeederChurnRate() / 100)
+	maxRequests := int(float64(t.simConfigs.TotalNumberOfNodes()) * t.simConfigs.TargetFeederMaxRequestsRate() / 100)
+	kp, ki := t.simConfigs.TargetFeederKp(), t.simConfigs.TargetFeederKi()
+
+	// Utilization and absolute error accumulated in the second half of each target's window (steady state).
+	steadyUtilization := make([]float64, len(targets))
+	steadyAbsError := make([]float64, len(targets))
+	steadySamples := make([]int, len(targets))
+
+	errorIntegral := float64(0)
+	tick := 0
+	for {
+		select {
+		case newTickChan, more := <-ticksChannel: // Send all the requests for this tickChan
+			if more {
+				currentSuperTick := tick / superTicksSize
+				if currentSuperTick >= len(targets) {
+					currentSuperTick = len(targets) - 1
+				}
+
+				target := targets[currentSuperTick] / 100
+				utilization := t.collector.SystemUsedResourcesRatio()
+				utilizationError := target - utilization
+				if tick%superTicksSize >= superTicksSize/2 {
+					steadyUtilization[currentSuperTick] += utilization
+					steadyAbsError[currentSuperTick] += math.Abs(utilizationError)
+					steadySamples[currentSuperTick]++
+				}
+
+				// PI controller: the output is the ratio of the system's resources to deploy (or release).
+				requests := 0
+				if t.avgRequestRatio > 0 {
+					integral := errorIntegral + utilizationError
+					output := kp*utilizationError + ki*integral
+					requests = int(math.Round(output / t.avgRequestRatio))
+					if requests < maxRequests && requests > -maxRequests {
+						errorIntegral = integral // Only integrate when the output isn't saturated (anti-windup).
+					}
+				}
+
+				deployRequests, stopRequests := churnRequests, churnRequests
+				if requests > 0 {
+					deployRequests += requests
+				} else {
+					stopRequests -= requests
+				}
+				deployRequests = int(math.Min(float64(deployRequests), float64(maxRequests)))
+				stopRequests = int(math.Min(float64(stopRequests), float64(maxRequests)))
+
+				util.Log.Debugf(util.LogTag(logTargetFeederTag)+"Target: %.3f, Utilization: %.3f, Deploys: %d, Stops: %d",
+					target, utilization, deployRequests, stopRequests)
+
+				for r := 0; r < deployRequests; r++ { // Run Container Requests
+					profile, resources := t.generateResourcesProfile()
+					newTickChan <- t.deployTask(profile, resources, t.tenants.Tenant(t.randomGenerator),
+						t.images.Image(t.randomGenerator))
+				}
+
+				for s := 0; s < stopRequests; s++ { // Stop Containers Requests
+					profile, _ := t.generateResourcesProfile()
+					newTickChan <- t.stopTask(profile)
+				}
+
+				close(newTickChan) // No more user requests for this tick
+			} else { // Simulator closed ticks channel
+				for i, target := range targets {
+					if steadySamples[i] == 0 {
+						continue
+					}
+					util.Log.Infof(util.LogTag(logTargetFeederTag)+"Target: %.2f%%, Steady Utilization: %.2f%%, Mean Abs Error: %.2f%%",
+						target, 100*steadyUtilization[i]/float64(steadySamples[i]), 100*steadyAbsError[i]/float64(steadySamples[i]))
+				}
+				return // Stop feeding engine
+			}
+		}
+		tick++
+	}
+}
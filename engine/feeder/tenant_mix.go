@@ -0,0 +1,41 @@
+package feeder
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"math/rand"
+)
+
+// tenantMix assigns the requests to the configured tenants following the tenants percentages.
+type tenantMix struct {
+	names       []string // Tenants names.
+	percentages []int    // Cumulative percentage of each tenant.
+}
+
+// newTenantMix creates a new tenant mix.
+func newTenantMix(tenants []configuration.Tenant) *tenantMix {
+	res := &tenantMix{
+		names:       make([]string, len(tenants)),
+		percentages: make([]int, len(tenants)),
+	}
+	acc := 0
+	for i, tenant := range tenants {
+		acc += tenant.Percentage
+		res.names[i] = tenant.Name
+		res.percentages[i] = acc
+	}
+	return res
+}
+
+// Tenant returns a tenant drawn from the mix, or an empty tenant when there are no tenants configured.
+func (t *tenantMix) Tenant(randomGenerator *rand.Rand) string {
+	if len(t.names) == 0 {
+		return ""
+	}
+	randTenant := randomGenerator.Intn(101)
+	for i, percentage := range t.percentages {
+		if randTenant <= percentage {
+			return t.names[i]
+		}
+	}
+	return t.names[len(t.names)-1]
+}
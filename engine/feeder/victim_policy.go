@@ -0,0 +1,310 @@
+package feeder
+
+import (
+	"container/heap"
+	"container/list"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// containerRunning represents a container, deployed by a feeder, that is running in the system.
+type containerRunning struct {
+	containerID  string          // Container's ID.
+	injectedNode *node.Node      // Node where the deploy request was injected.
+	supplierIP   string          // IP of the node where the container is running.
+	profile      int             // Request profile used to deploy the container.
+	resources    types.Resources // Resources used by the container.
+	startTime    time.Duration   // Simulation time when the container started.
+	seq          int64           // Sequence number used to order the containers started at the same time.
+	poolIndex    int             // Position of the container in the policy's pool, -1 when it left (guarded by the policy).
+}
+
+// victimPolicy selects the running container that is stopped by each stop request.
+type victimPolicy interface {
+	// Add registers a new running container.
+	Add(container *containerRunning)
+	// Remove selects a running container to be stopped, given the request profile drawn for the stop request.
+	Remove(profile int) (*containerRunning, error)
+	// Ended removes a registered container that ended without a stop request, so it is never selected.
+	// It does nothing if the container was already selected by a stop request.
+	Ended(container *containerRunning)
+}
+
+// victimPolicyFactory represents a method that creates new victim policies.
+type victimPolicyFactory func(randomGenerator *rand.Rand) victimPolicy
+
+// victimPolicies holds all the victim policies available.
+var victimPolicies = map[string]victimPolicyFactory{
+	"lifo":   func(_ *rand.Rand) victimPolicy { return newProfileVictimPolicy(true) },
+	"fifo":   func(_ *rand.Rand) victimPolicy { return newProfileVictimPolicy(false) },
+	"random": newRandomVictimPolicy,
+	"oldest": func(_ *rand.Rand) victimPolicy { return newOldestVictimPolicy() },
+	"node":   newNodeVictimPolicy,
+}
+
+// newVictimPolicy creates the victim policy with the given name.
+func newVictimPolicy(policyName string, randomGenerator *rand.Rand) (victimPolicy, error) {
+	factory, exist := victimPolicies[policyName]
+	if !exist {
+		return nil, fmt.Errorf("invalid %s victim policy", policyName)
+	}
+	return factory(randomGenerator), nil
+}
+
+var errNoContainerRunning = errors.New("no request running")
+
+// ============================== Per Profile (LIFO and FIFO) ===============================
+
+// profileVictimPolicy stops the most recent (LIFO) or the least recent (FIFO) container started
+// with the drawn request profile.
+type profileVictimPolicy struct {
+	mutex      sync.Mutex
+	lifo       bool
+	containers map[int]*list.List                  // Request profile<->Containers in the order they started.
+	elements   map[*containerRunning]*list.Element // Container<->Container's element in its profile's list.
+}
+
+func newProfileVictimPolicy(lifo bool) *profileVictimPolicy {
+	return &profileVictimPolicy{
+		mutex:      sync.Mutex{},
+		lifo:       lifo,
+		containers: make(map[int]*list.List),
+		elements:   make(map[*containerRunning]*list.Element),
+	}
+}
+
+func (p *profileVictimPolicy) Add(container *containerRunning) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	containers, exist := p.containers[container.profile]
+	if !exist {
+		containers = list.New()
+		p.containers[container.profile] = containers
+	}
+	p.elements[container] = containers.PushBack(container)
+}
+
+func (p *profileVictimPolicy) Remove(profile int) (*containerRunning, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	containers, exist := p.containers[profile]
+	if !exist || containers.Len() == 0 {
+		return nil, errNoContainerRunning
+	}
+
+	victim := containers.Front()
+	if p.lifo {
+		victim = containers.Back()
+	}
+	res := containers.Remove(victim).(*containerRunning)
+	delete(p.elements, res)
+	return res, nil
+}
+
+func (p *profileVictimPolicy) Ended(container *containerRunning) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if element, exist := p.elements[container]; exist {
+		p.containers[container.profile].Remove(element)
+		delete(p.elements, container)
+	}
+}
+
+// ==================================== Uniform Random ======================================
+
+// randomVictimPolicy stops a container chosen uniformly at random between all the running containers.
+type randomVictimPolicy struct {
+	mutex           sync.Mutex
+	randomGenerator *rand.Rand
+	containers      []*containerRunning
+}
+
+func newRandomVictimPolicy(randomGenerator *rand.Rand) victimPolicy {
+	return &randomVictimPolicy{
+		mutex:           sync.Mutex{},
+		randomGenerator: randomGenerator,
+		containers:      make([]*containerRunning, 0),
+	}
+}
+
+func (r *randomVictimPolicy) Add(container *containerRunning) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	container.poolIndex = len(r.containers)
+	r.containers = append(r.containers, container)
+}
+
+func (r *randomVictimPolicy) Remove(_ int) (*containerRunning, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.containers) == 0 {
+		return nil, errNoContainerRunning
+	}
+	var res *containerRunning
+	r.containers, res = removeFromPool(r.containers, r.randomGenerator.Intn(len(r.containers)))
+	return res, nil
+}
+
+func (r *randomVictimPolicy) Ended(container *containerRunning) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if container.poolIndex >= 0 {
+		r.containers, _ = removeFromPool(r.containers, container.poolIndex)
+	}
+}
+
+// removeFromPool removes the container at the index from the unordered pool, moving the pool's last container
+// to its position. It returns the pool and the container removed.
+func removeFromPool(pool []*containerRunning, index int) ([]*containerRunning, *containerRunning) {
+	res, last := pool[index], pool[len(pool)-1]
+	pool[index], last.poolIndex = last, index
+	pool[len(pool)-1] = nil
+	res.poolIndex = -1
+	return pool[:len(pool)-1], res
+}
+
+// ===================================== Oldest First =======================================
+
+// containersHeap is a min-heap of containers ordered by their start time.
+type containersHeap []*containerRunning
+
+func (h containersHeap) Len() int { return len(h) }
+
+func (h containersHeap) Less(i, j int) bool {
+	if h[i].startTime == h[j].startTime {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].startTime < h[j].startTime
+}
+
+func (h containersHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].poolIndex, h[j].poolIndex = i, j
+}
+
+func (h *containersHeap) Push(x interface{}) {
+	container := x.(*containerRunning)
+	container.poolIndex = len(*h)
+	*h = append(*h, container)
+}
+
+func (h *containersHeap) Pop() interface{} {
+	old := *h
+	res := old[len(old)-1]
+	old[len(old)-1] = nil
+	res.poolIndex = -1
+	*h = old[:len(old)-1]
+	return res
+}
+
+// oldestVictimPolicy stops the container, between all the running containers, that is running for longer.
+type oldestVictimPolicy struct {
+	mutex      sync.Mutex
+	containers containersHeap
+}
+
+func newOldestVictimPolicy() *oldestVictimPolicy {
+	return &oldestVictimPolicy{
+		mutex:      sync.Mutex{},
+		containers: make(containersHeap, 0),
+	}
+}
+
+func (o *oldestVictimPolicy) Add(container *containerRunning) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	heap.Push(&o.containers, container)
+}
+
+func (o *oldestVictimPolicy) Remove(_ int) (*containerRunning, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.containers.Len() == 0 {
+		return nil, errNoContainerRunning
+	}
+	return heap.Pop(&o.containers).(*containerRunning), nil
+}
+
+func (o *oldestVictimPolicy) Ended(container *containerRunning) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if container.poolIndex >= 0 {
+		heap.Remove(&o.containers, container.poolIndex)
+	}
+}
+
+// ===================================== Node Targeted ======================================
+
+// nodeVictimPolicy chooses uniformly at random one of the nodes running containers and stops one of its
+// containers, so every node is targeted with the same frequency independently of the containers it runs.
+type nodeVictimPolicy struct {
+	mutex           sync.Mutex
+	randomGenerator *rand.Rand
+	nodes           []string                       // Suppliers' IPs that have containers running.
+	nodesIndex      map[string]int                 // Supplier's IP<->Index in the nodes slice.
+	containers      map[string][]*containerRunning // Supplier's IP<->Containers running in the supplier.
+}
+
+func newNodeVictimPolicy(randomGenerator *rand.Rand) victimPolicy {
+	return &nodeVictimPolicy{
+		mutex:           sync.Mutex{},
+		randomGenerator: randomGenerator,
+		nodes:           make([]string, 0),
+		nodesIndex:      make(map[string]int),
+		containers:      make(map[string][]*containerRunning),
+	}
+}
+
+func (n *nodeVictimPolicy) Add(container *containerRunning) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if _, exist := n.nodesIndex[container.supplierIP]; !exist {
+		n.nodesIndex[container.supplierIP] = len(n.nodes)
+		n.nodes = append(n.nodes, container.supplierIP)
+	}
+	container.poolIndex = len(n.containers[container.supplierIP])
+	n.containers[container.supplierIP] = append(n.containers[container.supplierIP], container)
+}
+
+func (n *nodeVictimPolicy) Remove(_ int) (*containerRunning, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if len(n.nodes) == 0 {
+		return nil, errNoContainerRunning
+	}
+	supplierIP := n.nodes[n.randomGenerator.Intn(len(n.nodes))]
+	return n.removeContainer(supplierIP, n.randomGenerator.Intn(len(n.containers[supplierIP]))), nil
+}
+
+func (n *nodeVictimPolicy) Ended(container *containerRunning) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if container.poolIndex >= 0 {
+		n.removeContainer(container.supplierIP, container.poolIndex)
+	}
+}
+
+// removeContainer removes the container, at the given index, of the node. A node without containers running
+// is removed, so it isn't chosen again.
+func (n *nodeVictimPolicy) removeContainer(supplierIP string, containerIndex int) *containerRunning {
+	containers, res := removeFromPool(n.containers[supplierIP], containerIndex)
+	if len(containers) > 0 {
+		n.containers[supplierIP] = containers
+		return res
+	}
+
+	// The node has no more containers running.
+	delete(n.containers, supplierIP)
+	nodeIndex := n.nodesIndex[supplierIP]
+	lastNode := n.nodes[len(n.nodes)-1]
+	n.nodes[nodeIndex] = lastNode
+	n.nodesIndex[lastNode] = nodeIndex
+	n.nodes = n.nodes[:len(n.nodes)-1]
+	delete(n.nodesIndex, supplierIP)
+	return res
+}
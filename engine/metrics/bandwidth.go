@@ -0,0 +1,49 @@
+package metrics
+
+// uploadDownload returns the bytes sent (upload) and the bytes received (download) by each node during the
+// whole simulation.
+func (sim *simulationData) uploadDownload() ([]float64, []float64) {
+	if len(sim.snapshots) == 0 {
+		return nil, nil
+	}
+
+	upload := make([]float64, len(sim.snapshots[0].NodesMetrics))
+	download := make([]float64, len(sim.snapshots[0].NodesMetrics))
+	for i := range sim.snapshots {
+		for node := range sim.snapshots[i].NodesMetrics {
+			if node < len(upload) {
+				upload[node] += sim.snapshots[i].NodesMetrics[node].TotalBandwidthUsedOnSending()
+				download[node] += sim.snapshots[i].NodesMetrics[node].TotalBandwidthUsedOnReceiving()
+			}
+		}
+	}
+	return upload, download
+}
+
+// imagesPulled returns the bytes of the images pulled from the registry by each node during the whole simulation.
+func (sim *simulationData) imagesPulled() []float64 {
+	if len(sim.snapshots) == 0 {
+		return nil
+	}
+
+	pulled := make([]float64, len(sim.snapshots[0].NodesMetrics))
+	for i := range sim.snapshots {
+		for node, nodePulled := range sim.snapshots[i].TotalImagesPulledSizeByNode() {
+			if node < len(pulled) {
+				pulled[node] += nodePulled
+			}
+		}
+	}
+	return pulled
+}
+
+// maxValue returns the maximum of the values (0 if there are no values).
+func maxValue(values []float64) float64 {
+	res := 0.0
+	for _, value := range values {
+		if value > res {
+			res = value
+		}
+	}
+	return res
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,6 +25,9 @@ const simulationDirBaseName = "sim-"
 // simulationDirSuffixFormat is the format for the suffix of the simulations output directories.
 const simulationDirSuffixFormat = "2006-01-02_15h04m05s"
 
+// maxTenantsPrinted is the maximum number of tenants whose metrics are printed.
+const maxTenantsPrinted = 10
+
 // simulationData represents a complete simulation data.
 type simulationData struct {
 	label          string   // Label to identify the simulation.
@@ -50,6 +54,7 @@ type Collector struct {
 	numNodes       int               // Number of engine nodes.
 	currSimulation *simulationData   // Current simulation.
 	simulations    []*simulationData // Contains all the simulations identified by its label.
+	lookupsCounter int64             // Number of overlay's lookups done in the current simulation (for the paths' sampling).
 
 	simulatorConfigs *configuration.Configuration
 	outputDirPath    string // Output directory path.
@@ -74,6 +79,7 @@ func (c *Collector) InitNewSimulation(simLabel string, nodesMaxRes []types.Resou
 		snapshots: make([]Global, 1),
 	}
 	c.currSimulation = newSimulation
+	atomic.StoreInt64(&c.lookupsCounter, 0)
 
 	dirFullPath, err := ioutil.TempDir("", metricsTempDirName+newSimulation.label+"-")
 	if err != nil {
@@ -101,10 +107,30 @@ func (c *Collector) EmptyGetOfferMessage(amount int64) {
 	}
 }
 
-// MessageReceived increments the number of messages received by the node.
-func (c *Collector) MessageReceived(nodeIndex int, amount int64, requestSizeBytes int64) {
+// MessageReceived increments the number of messages, of the given type, received by the node (to) and sent
+// by the other node (from).
+func (c *Collector) MessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.MessageReceived(fromNodeIndex, toNodeIndex, msgType, amount, requestSizeBytes)
+	}
+}
+
+// LookupMessageReceived increments the number of messages, of the given type, received by the node (to) and
+// sent by the other node (from) due to an overlay's lookup.
+func (c *Collector) LookupMessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
 	if activeGlobal, err := c.activeGlobal(); err == nil {
-		activeGlobal.MessageReceived(nodeIndex, amount, requestSizeBytes)
+		activeGlobal.LookupMessageReceived(fromNodeIndex, toNodeIndex, msgType, amount, requestSizeBytes)
+	}
+}
+
+// LookupDone registers an overlay's lookup done by the node, given the nodes (path) that received the
+// lookup's requests and the lookup's latency (ms). The path is captured (copied) for one of each
+// LookupPathsSampling lookups.
+func (c *Collector) LookupDone(fromNodeIndex int, path []int, latency float64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		sampling := int64(c.simulatorConfigs.LookupPathsSamplingInterval())
+		sampled := sampling > 0 && atomic.AddInt64(&c.lookupsCounter, 1)%sampling == 0
+		activeGlobal.LookupDone(fromNodeIndex, path, latency, sampled)
 	}
 }
 
@@ -116,9 +142,9 @@ func (c *Collector) SetNodeState(nodeIndex int, freeResources types.Resources, t
 }
 
 // CreateRunRequest creates a new run request in order to gather its metrics.
-func (c *Collector) CreateRunRequest(nodeIndex int, requestID string, resources types.Resources) {
+func (c *Collector) CreateRunRequest(nodeIndex int, requestID string, source string, tenant string, resources types.Resources) {
 	if activeGlobal, err := c.activeGlobal(); err == nil {
-		activeGlobal.CreateRunRequest(nodeIndex, requestID, resources)
+		activeGlobal.CreateRunRequest(nodeIndex, requestID, source, tenant, resources)
 	}
 }
 
@@ -136,6 +162,74 @@ func (c *Collector) ArchiveRunRequest(requestID string, succeeded bool) {
 	}
 }
 
+// MaintenanceMessageReceived increments the number of messages, of the given type, received by the node (to)
+// and sent by the other node (from) to maintain the overlay.
+func (c *Collector) MaintenanceMessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.MaintenanceMessageReceived(fromNodeIndex, toNodeIndex, msgType, amount, requestSizeBytes)
+	}
+}
+
+// OverlayStaleLookup increments the number of lookups that ended in a wrong node, because the overlay
+// wasn't repaired yet.
+func (c *Collector) OverlayStaleLookup() {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.IncrOverlayStaleLookups()
+	}
+}
+
+// SetNodesArcLength sets the fraction of the chord's ring owned by each node.
+func (c *Collector) SetNodesArcLength(arcLengths []float64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.SetNodesArcLength(arcLengths)
+	}
+}
+
+// CapacityChanged registers a change of the node's capacity.
+func (c *Collector) CapacityChanged(nodeIndex int, capacity types.Resources) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.CapacityChanged(nodeIndex, capacity)
+	}
+}
+
+// ContainersEvicted increments the number of containers evicted because their node's capacity dropped.
+func (c *Collector) ContainersEvicted(amount int64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.IncrContainersEvicted(amount)
+	}
+}
+
+// ContainersEnded increments the number of containers that ended on their own (reported by the docker events).
+func (c *Collector) ContainersEnded(finished, crashed, oomKilled int64) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.IncrContainersEnded(finished, crashed, oomKilled)
+	}
+}
+
+// ImageRequested registers an image needed to launch a container in the node, when it isn't in the node's image
+// cache it is pulled from the registry.
+func (c *Collector) ImageRequested(nodeIndex int, cacheHit bool, pulledBytes int64, pullTime time.Duration) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.ImageRequested(nodeIndex, cacheHit, pulledBytes, pullTime)
+	}
+}
+
+// ContainerLaunched registers a container launched for the run request, the time spent pulling the container's
+// image is added to the request's latency.
+func (c *Collector) ContainerLaunched(requestID string, pullTime time.Duration) {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		activeGlobal.ContainerLaunched(requestID, pullTime)
+	}
+}
+
+// SystemUsedResourcesRatio returns the ratio of the system's resources used, since the last node's state update.
+func (c *Collector) SystemUsedResourcesRatio() float64 {
+	if activeGlobal, err := c.activeGlobal(); err == nil {
+		return activeGlobal.SystemUsedResourcesRatio()
+	}
+	return 0
+}
+
 // ================================= Collector Management Methods ===================================
 
 // CreateNewGlobalSnapshot creates a snapshot of the system's current metrics and initialize a new one.
@@ -192,9 +286,29 @@ func (c *Collector) Print() {
 	for _, simData := range c.simulations {
 		totalRunRequests := int64(0)
 		totalRunRequestsSucceeded := int64(0)
-		for _, global := range simData.snapshots {
-			totalRunRequests += global.TotalRunRequests()
-			totalRunRequestsSucceeded += global.TotalRunRequestsSucceeded()
+		totalCapacityChanges, totalContainersEvicted := int64(0), int64(0)
+		totalFinished, totalCrashed, totalOOMKilled := int64(0), int64(0), int64(0)
+		totalImageRequests, totalImageCacheHits := int64(0), int64(0)
+		totalImagePullsSize, totalImagePullsTime := int64(0), time.Duration(0)
+		totalRequestsLatency, totalRequestsLatencySamples := time.Duration(0), int64(0)
+		totalMaintenanceMsgs, totalStaleLookups := int64(0), int64(0)
+		totalBandwidth := 0.0
+		for i := range simData.snapshots {
+			totalRunRequests += simData.snapshots[i].TotalRunRequests()
+			totalRunRequestsSucceeded += simData.snapshots[i].TotalRunRequestsSucceeded()
+			totalCapacityChanges += simData.snapshots[i].TotalCapacityChanges()
+			totalContainersEvicted += simData.snapshots[i].TotalContainersEvicted()
+			finished, crashed, oomKilled := simData.snapshots[i].TotalContainersEnded()
+			totalFinished, totalCrashed, totalOOMKilled = totalFinished+finished, totalCrashed+crashed, totalOOMKilled+oomKilled
+			imageRequests, imageCacheHits := simData.snapshots[i].TotalImageRequests()
+			totalImageRequests, totalImageCacheHits = totalImageRequests+imageRequests, totalImageCacheHits+imageCacheHits
+			imagePullsSize, imagePullsTime := simData.snapshots[i].TotalImagePulls()
+			totalImagePullsSize, totalImagePullsTime = totalImagePullsSize+imagePullsSize, totalImagePullsTime+imagePullsTime
+			requestsLatency, requestsLatencySamples := simData.snapshots[i].TotalRunRequestsLatency()
+			totalRequestsLatency, totalRequestsLatencySamples = totalRequestsLatency+requestsLatency, totalRequestsLatencySamples+requestsLatencySamples
+			totalMaintenanceMsgs += simData.snapshots[i].TotalOverlayMaintenanceMsgs()
+			totalStaleLookups += simData.snapshots[i].TotalOverlayStaleLookups()
+			totalBandwidth += simData.snapshots[i].TotalBandwidthUsedOnReceiving()
 		}
 
 		fmt.Printf("##################################################################\n")
@@ -203,11 +317,117 @@ func (c *Collector) Print() {
 		fmt.Printf("Requests:               %d\n", totalRunRequests)
 		fmt.Printf("Requests Succeeded:     %d\n", totalRunRequestsSucceeded)
 		fmt.Printf("Requests Success Ratio: %.2f\n", float64(totalRunRequestsSucceeded)/float64(totalRunRequests))
+		fmt.Printf("Bandwidth Received:     %.2f MB (%s)\n", totalBandwidth/(1024*1024), c.simulatorConfigs.MessageSizeModel())
+		if upload, download := simData.uploadDownload(); len(upload) > 0 {
+			fmt.Printf("Node Upload Max/Mean:   %.2f (max %.2f MB)\n", maxMeanRatio(upload), maxValue(upload)/(1024*1024))
+			fmt.Printf("Node Download Max/Mean: %.2f (max %.2f MB)\n", maxMeanRatio(download), maxValue(download)/(1024*1024))
+		}
+		messagesByType := simData.messagesByType()
+		for msgType := MessageType(0); msgType < numMessageTypes; msgType++ {
+			if messagesByType.Amount[msgType] > 0 {
+				fmt.Printf("  %-24s %d msgs, %.2f MB\n", msgType.String()+":", messagesByType.Amount[msgType],
+					float64(messagesByType.Size[msgType])/(1024*1024))
+			}
+		}
+		if totalCapacityChanges > 0 {
+			fmt.Printf("Capacity Changes:       %d\n", totalCapacityChanges)
+			fmt.Printf("Containers Evicted:     %d\n", totalContainersEvicted)
+			fmt.Printf("Final Capacity Ratio:   %.2f\n", simData.snapshots[len(simData.snapshots)-1].SystemCapacityRatio())
+		}
+		if c.simulatorConfigs.HasContainerEvents() {
+			fmt.Printf("Containers Finished:    %d\n", totalFinished)
+			fmt.Printf("Containers Crashed:     %d\n", totalCrashed)
+			fmt.Printf("Containers OOM-Killed:  %d\n", totalOOMKilled)
+		}
+		if totalImageRequests > 0 {
+			imagePulls := totalImageRequests - totalImageCacheHits
+			fmt.Printf("Image Cache Hit Ratio:  %.2f\n", float64(totalImageCacheHits)/float64(totalImageRequests))
+			fmt.Printf("Image Pulls:            %d (%.2f MB)\n", imagePulls, float64(totalImagePullsSize)/(1024*1024))
+			if imagePulls > 0 {
+				fmt.Printf("Image Pull Time Avg:    %s\n", totalImagePullsTime/time.Duration(imagePulls))
+				if totalRequestsLatencySamples > 0 {
+					fmt.Printf("Request Latency Avg:    %s\n", totalRequestsLatency/time.Duration(totalRequestsLatencySamples))
+				}
+				fmt.Printf("Node Image Pulls Max:   %.2f MB\n", maxValue(simData.imagesPulled())/(1024*1024))
+			}
+		}
+
+		if totalMaintenanceMsgs > 0 {
+			fmt.Printf("Overlay Maint. Msgs:    %d\n", totalMaintenanceMsgs)
+			fmt.Printf("Overlay Stale Lookups:  %d\n", totalStaleLookups)
+		}
+		if hopsAvg, maxHops := hopsAverage(simData.lookupsHops()); hopsAvg > 0 {
+			lookupMessages, appMessages := simData.relayMessages()
+			fmt.Printf("Lookup Hops Avg/Max:    %.2f/%d\n", hopsAvg, maxHops)
+			fmt.Printf("Lookup Msgs Max/Mean:   %.2f\n", maxMeanRatio(lookupMessages))
+			fmt.Printf("App Msgs Max/Mean:      %.2f\n", maxMeanRatio(appMessages))
+		}
+		if latencyAvg, maxLatency := simData.lookupsLatency(); latencyAvg > 0 {
+			fmt.Printf("Lookup Latency Avg/Max: %.1f/%.1f ms\n", latencyAvg, maxLatency)
+		}
+		if c.simulatorConfigs.LookupPathsSamplingInterval() > 0 {
+			c.saveLookupPaths(simData)
+		}
+		arcLengths, messagesReceived := simData.ringArcsLoad()
+		if arcsGini := giniCoefficient(arcLengths); arcsGini > 1e-6 { // Only when the ring isn't (nearly) balanced.
+			fmt.Printf("Ring Arcs Max/Mean:     %.2f\n", maxMeanRatio(arcLengths))
+			fmt.Printf("Ring Arcs Gini:         %.3f\n", arcsGini)
+			fmt.Printf("Messages Max/Mean:      %.2f\n", maxMeanRatio(messagesReceived))
+			fmt.Printf("Arcs/Messages Corr.:    %.2f\n", pearsonCorrelation(arcLengths, messagesReceived))
+		}
+
+		sourcesMetrics := simData.requestsPerSource()
+		if len(sourcesMetrics) > 1 {
+			fmt.Printf("Requests per Source:\n")
+			for _, source := range sourcesMetrics {
+				fmt.Printf("  %-20s Requests: %-8d Success Ratio: %.2f  Avg Messages: %.2f\n", source.name,
+					source.requests, source.SuccessRatio(), source.AvgMessagesPerRequest())
+			}
+		}
+
+		tenantsMetrics := simData.requestsPerTenant()
+		if len(tenantsMetrics) > 1 {
+			tenantsSuccessRatio := make([]float64, len(tenantsMetrics))
+			for i, tenant := range tenantsMetrics {
+				tenantsSuccessRatio[i] = tenant.SuccessRatio()
+			}
+			fmt.Printf("Tenants:                %d\n", len(tenantsMetrics))
+			fmt.Printf("Tenants Jain's Index:   %.3f\n", jainFairnessIndex(tenantsSuccessRatio))
+
+			// Only the tenants with more requests are printed.
+			sort.SliceStable(tenantsMetrics, func(i, j int) bool {
+				return tenantsMetrics[i].requests > tenantsMetrics[j].requests
+			})
+			if len(tenantsMetrics) > maxTenantsPrinted {
+				fmt.Printf("Requests per Tenant (top %d):\n", maxTenantsPrinted)
+				tenantsMetrics = tenantsMetrics[:maxTenantsPrinted]
+			} else {
+				fmt.Printf("Requests per Tenant:\n")
+			}
+			for _, tenant := range tenantsMetrics {
+				fmt.Printf("  %-20s Requests: %-8d Success Ratio: %.2f  Allocated: <%d;%d>\n", tenant.name,
+					tenant.requests, tenant.SuccessRatio(), tenant.resourcesAllocated.CPUs, tenant.resourcesAllocated.Memory)
+			}
+		}
 	}
 
 	c.plotGraphics() // Plot the graphics for the simulations
 }
 
+// saveLookupPaths writes the lookups' paths sampled in the simulation into a JSON file.
+func (c *Collector) saveLookupPaths(simData *simulationData) {
+	jsonBytes, err := json.Marshal(simData.lookupsPaths())
+	if err != nil {
+		panic(errors.New("can't marshall the lookups' paths, error: " + err.Error()))
+	}
+	dirPath := filepath.Join(c.outputDirPath, "Overlay")
+	os.MkdirAll(dirPath, os.ModePerm)
+	err = ioutil.WriteFile(filepath.Join(dirPath, "LookupPaths_"+simData.label+".json"), jsonBytes, 0644)
+	if err != nil {
+		panic(errors.New("can't write the lookups' paths to disk, error: " + err.Error()))
+	}
+}
+
 // activeGlobal returns the current global snapshot that is gathering metrics.
 func (c *Collector) activeGlobal() (*Global, error) {
 	if c.currSimulation == nil {
@@ -344,6 +564,18 @@ func (c *Collector) plotGraphics() {
 		goroutinePool.JobDone()
 	}
 
+	goroutinePool.WaitCount(1)
+	goroutinePool.JobQueue <- func() {
+		c.plotLookupHopsHistogram()
+		c.plotBandwidthByMessageTypeOverTime()
+		c.plotUploadDownloadByNode()
+		c.plotUploadDownloadOverTime()
+		c.plotImageCacheOverTime()
+		c.plotLookupLatencyHistogram()
+		c.plotRelayMessagesByNode()
+		goroutinePool.JobDone()
+	}
+
 	goroutinePool.WaitAll() // Wait for all the plots to be completed.
 	goroutinePool.Release() // Release goroutinePool resources.
 	goroutinePool = nil
@@ -782,6 +782,222 @@ func (c *Collector) plotEmptyGetOfferMessages() {
 	graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, "Debug_EmptyGetOffer"))
 }
 
+func (c *Collector) plotLookupHopsHistogram() {
+	const title = "Overlay Lookups Hops"
+	const xLabel = "Hops"
+	const yLabel = "Lookups (%)"
+	const outDir = "Overlay"
+
+	plotRes := graphics.NewPlot(title, xLabel, yLabel, true)
+	dataPoints := make([]interface{}, 0)
+	for _, simData := range c.simulations {
+		lookupsHops := simData.lookupsHops()
+		totalLookups := int64(0)
+		for _, lookups := range lookupsHops {
+			totalLookups += lookups
+		}
+		if totalLookups == 0 {
+			continue
+		}
+
+		simulationPts := make(plotter.XYs, len(lookupsHops))
+		for hops, lookups := range lookupsHops {
+			simulationPts[hops].X = float64(hops)
+			simulationPts[hops].Y = float64(lookups) / float64(totalLookups) * 100
+		}
+		dataPoints = append(dataPoints, visualStrategyName(simData.label), simulationPts)
+	}
+	if len(dataPoints) == 0 {
+		return
+	}
+	plotutil.AddLinePoints(plotRes, dataPoints...)
+
+	graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, outDir, "LookupsHopsHistogram"))
+}
+
+func (c *Collector) plotLookupLatencyHistogram() {
+	const title = "Overlay Lookups Latency"
+	const xLabel = "Latency (ms)"
+	const yLabel = "Lookups (%)"
+	const outDir = "Overlay"
+
+	plotRes := graphics.NewPlot(title, xLabel, yLabel, true)
+	dataPoints := make([]interface{}, 0)
+	for _, simData := range c.simulations {
+		if latencyAvg, _ := simData.lookupsLatency(); latencyAvg == 0 {
+			continue // The nodes don't have network coordinates.
+		}
+		lookupsLatencies := simData.lookupsLatencies()
+		totalLookups := int64(0)
+		for _, lookups := range lookupsLatencies {
+			totalLookups += lookups
+		}
+
+		simulationPts := make(plotter.XYs, len(lookupsLatencies))
+		for bin, lookups := range lookupsLatencies {
+			simulationPts[bin].X = float64(bin) * lookupLatencyBinSize
+			simulationPts[bin].Y = float64(lookups) / float64(totalLookups) * 100
+		}
+		dataPoints = append(dataPoints, visualStrategyName(simData.label), simulationPts)
+	}
+	if len(dataPoints) == 0 {
+		return
+	}
+	plotutil.AddLines(plotRes, dataPoints...)
+
+	graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, outDir, "LookupsLatencyHistogram"))
+}
+
+// plotBandwidthByMessageTypeOverTime plots, for each simulation, the bandwidth used by each message's type over
+// time, stacked on top of each other (the top line is the total bandwidth).
+func (c *Collector) plotBandwidthByMessageTypeOverTime() {
+	const title = "Bandwidth per Message Type (%s)"
+	const xLabel = "Time (minutes)"
+	const yLabel = "Bandwidth (MB)"
+	const outDir = "Bandwidth"
+
+	for _, simData := range c.simulations {
+		messagesByType := simData.messagesByType()
+		stackedPts := make([]float64, len(simData.snapshots))
+		dataPoints := make([]interface{}, 0)
+		for msgType := MessageType(0); msgType < numMessageTypes; msgType++ {
+			if messagesByType.Amount[msgType] == 0 {
+				continue
+			}
+			msgTypePts := make(plotter.XYs, len(simData.snapshots))
+			for i := range msgTypePts {
+				_, size := simData.snapshots[i].MessagesReceivedByType(msgType)
+				stackedPts[i] += float64(size) / (1024 * 1024)
+				msgTypePts[i].X = simData.snapshots[i].EndTime().Minutes()
+				msgTypePts[i].Y = stackedPts[i]
+			}
+			dataPoints = append(dataPoints, msgType.String(), msgTypePts)
+		}
+		if len(dataPoints) == 0 {
+			continue
+		}
+
+		plotRes := graphics.NewPlot(fmt.Sprintf(title, visualStrategyName(simData.label)), xLabel, yLabel, true)
+		plotutil.AddLines(plotRes, dataPoints...)
+
+		graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight,
+			generatePNGFileName(c.outputDirPath, outDir, "BandwidthPerMessageType_"+visualStrategyName(simData.label)))
+	}
+}
+
+// plotUploadDownloadByNode plots, for each simulation, the distribution of the bytes sent (upload) and
+// received (download) by the nodes.
+func (c *Collector) plotUploadDownloadByNode() {
+	const title = "Bandwidth Used per Node (%s)"
+	const xLabel = ""
+	const yLabel = "Bandwidth (bytes)"
+	const outDir = "Bandwidth"
+
+	for _, simData := range c.simulations {
+		upload, download := simData.uploadDownload()
+		if len(upload) == 0 {
+			continue
+		}
+
+		plotRes := graphics.NewPlot(fmt.Sprintf(title, visualStrategyName(simData.label)), xLabel, yLabel, false)
+		downloadBoxPlot, _ := plotter.NewBoxPlot(vg.Points(boxPlotWidth*3), 0, plotter.Values(download))
+		uploadBoxPlot, _ := plotter.NewBoxPlot(vg.Points(boxPlotWidth*3), 1, plotter.Values(upload))
+		plotRes.Add(downloadBoxPlot, uploadBoxPlot)
+		plotRes.NominalX("Download", "Upload")
+
+		graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight,
+			generatePNGFileName(c.outputDirPath, outDir, "UploadDownloadPerNode_"+visualStrategyName(simData.label)))
+	}
+}
+
+// plotUploadDownloadOverTime plots the maximum bytes sent (upload) and received (download) by a node in each
+// snapshot, i.e. the load of the most loaded nodes' links.
+func (c *Collector) plotUploadDownloadOverTime() {
+	const title = "Maximum Bandwidth Used by a Node"
+	const xLabel = "Time (minutes)"
+	const yLabel = "Bandwidth (bytes)"
+	const outDir = "Bandwidth"
+
+	plotRes := graphics.NewPlot(title, xLabel, yLabel, true)
+	dataPoints := make([]interface{}, 0)
+	for _, simData := range c.simulations {
+		uploadPts := make(plotter.XYs, len(simData.snapshots))
+		downloadPts := make(plotter.XYs, len(simData.snapshots))
+		for i := range simData.snapshots {
+			uploadPts[i].X = simData.snapshots[i].EndTime().Minutes()
+			uploadPts[i].Y = maxValue(simData.snapshots[i].TotalBandwidthUsedOnSendingByNode())
+			downloadPts[i].X = uploadPts[i].X
+			downloadPts[i].Y = maxValue(simData.snapshots[i].TotalBandwidthUsedOnReceivingByNode())
+		}
+		dataPoints = append(dataPoints, visualStrategyName(simData.label)+" Upload", uploadPts,
+			visualStrategyName(simData.label)+" Download", downloadPts)
+	}
+
+	plotutil.AddLines(plotRes, dataPoints...)
+
+	graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, outDir, "MaxUploadDownloadOverTime"))
+}
+
+// plotImageCacheOverTime plots the hit ratio of the nodes' image caches and the bytes pulled from the registry
+// in each snapshot.
+func (c *Collector) plotImageCacheOverTime() {
+	const xLabel = "Time (minutes)"
+	const outDir = "Images"
+
+	if !c.simulatorConfigs.HasImages() {
+		return
+	}
+
+	hitRatioPlot := graphics.NewPlot("Image Cache Hit Ratio", xLabel, "Hit Ratio", true)
+	pullsPlot := graphics.NewPlot("Images Pulled", xLabel, "Pulled (bytes)", true)
+	hitRatioPoints, pullsPoints := make([]interface{}, 0), make([]interface{}, 0)
+	for _, simData := range c.simulations {
+		hitRatioPts := make(plotter.XYs, len(simData.snapshots))
+		pullsPts := make(plotter.XYs, len(simData.snapshots))
+		for i := range simData.snapshots {
+			imageRequests, imageCacheHits := simData.snapshots[i].TotalImageRequests()
+			pullsSize, _ := simData.snapshots[i].TotalImagePulls()
+			hitRatioPts[i].X = simData.snapshots[i].EndTime().Minutes()
+			if imageRequests > 0 {
+				hitRatioPts[i].Y = float64(imageCacheHits) / float64(imageRequests)
+			}
+			pullsPts[i].X = hitRatioPts[i].X
+			pullsPts[i].Y = float64(pullsSize)
+		}
+		hitRatioPoints = append(hitRatioPoints, visualStrategyName(simData.label), hitRatioPts)
+		pullsPoints = append(pullsPoints, visualStrategyName(simData.label), pullsPts)
+	}
+
+	plotutil.AddLines(hitRatioPlot, hitRatioPoints...)
+	plotutil.AddLines(pullsPlot, pullsPoints...)
+
+	graphics.Save(hitRatioPlot, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, outDir, "ImageCacheHitRatioOverTime"))
+	graphics.Save(pullsPlot, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight, generatePNGFileName(c.outputDirPath, outDir, "ImagesPulledOverTime"))
+}
+
+func (c *Collector) plotRelayMessagesByNode() {
+	const title = "Messages Received per Node (%s)"
+	const xLabel = ""
+	const yLabel = "Messages"
+	const outDir = "Overlay"
+
+	for _, simData := range c.simulations {
+		lookupMessages, appMessages := simData.relayMessages()
+		if len(lookupMessages) == 0 {
+			continue
+		}
+
+		plotRes := graphics.NewPlot(fmt.Sprintf(title, visualStrategyName(simData.label)), xLabel, yLabel, false)
+		lookupBoxPlot, _ := plotter.NewBoxPlot(vg.Points(boxPlotWidth*3), 0, plotter.Values(lookupMessages))
+		appBoxPlot, _ := plotter.NewBoxPlot(vg.Points(boxPlotWidth*3), 1, plotter.Values(appMessages))
+		plotRes.Add(lookupBoxPlot, appBoxPlot)
+		plotRes.NominalX("Lookup", "Application")
+
+		graphics.Save(plotRes, linePlotOverTimePNGWidth, linePlotOverTimePNGHeight,
+			generatePNGFileName(c.outputDirPath, outDir, "RelayMessagesPerNode_"+visualStrategyName(simData.label)))
+	}
+}
+
 // ======================================== Auxiliary Functions ====================================
 
 func isOfferingBasedStrategy(strategyName string) bool {
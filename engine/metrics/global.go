@@ -28,6 +28,30 @@ type Global struct {
 	ResourcesRequested Resources `json:"ResourcesRequested"`
 	ResourcesAllocated Resources `json:"ResourcesAllocated"`
 
+	CapacityChanges   int64 `json:"CapacityChanges"`   // Number of changes of the nodes' capacity.
+	ContainersEvicted int64 `json:"ContainersEvicted"` // Number of containers evicted due to capacity drops.
+
+	ContainersFinished  int64 `json:"ContainersFinished"`  // Number of containers that finished on their own.
+	ContainersCrashed   int64 `json:"ContainersCrashed"`   // Number of containers that crashed.
+	ContainersOOMKilled int64 `json:"ContainersOOMKilled"` // Number of containers killed for running out of memory.
+
+	ImageRequests  int64         `json:"ImageRequests"`  // Number of images needed by the containers launched.
+	ImageCacheHits int64         `json:"ImageCacheHits"` // Number of images found in the node's image cache.
+	ImagePullsSize int64         `json:"ImagePullsSize"` // Bytes pulled from the registry on the cache misses.
+	ImagePullsTime time.Duration `json:"ImagePullsTime"` // Time spent pulling images from the registry.
+
+	OverlayMaintenanceMsgs int64 `json:"OverlayMaintenanceMsgs"` // Messages exchanged to maintain the overlay's ring.
+	OverlayStaleLookups    int64 `json:"OverlayStaleLookups"`    // Lookups that ended in a wrong node (ring not repaired).
+
+	MessagesByType MessagesByType `json:"MessagesByType"` // Number/size of the messages received of each type.
+
+	LookupsHops       []int64      `json:"LookupsHops"`       // Number of overlay's lookups done with each number of hops.
+	LookupsLatencies  []int64      `json:"LookupsLatencies"`  // Number of overlay's lookups in each latency's bin.
+	LookupsLatencySum float64      `json:"LookupsLatencySum"` // Sum of the overlay's lookups latencies (ms).
+	LookupsLatencyMax float64      `json:"LookupsLatencyMax"` // Maximum latency of an overlay's lookup (ms).
+	LookupPaths       []LookupPath `json:"LookupPaths"`       // Paths of the overlay's lookups sampled.
+	lookupsMutex      sync.Mutex   `json:"-"`
+
 	// Debug Performance Metrics
 	GetOffersRelayed       int64 `json:"GetOffersRelayed"`
 	EmptyGetOffersMessages int64 `json:"EmptyGetOffersMessages"`
@@ -72,7 +96,12 @@ func NewGlobalNext(numNodes int, prevGlobal *Global) *Global {
 	}
 
 	for index := range prevGlobal.NodesMetrics {
-		res.NodesMetrics[index] = *NewNode(prevGlobal.NodesMetrics[index].MaximumResources())
+		prevNode := &prevGlobal.NodesMetrics[index]
+		res.NodesMetrics[index] = *NewNode(prevNode.MaximumResources())
+		// The node's state is carried over until it is updated in the new window.
+		res.NodesMetrics[index].SetNodeState(prevNode.FreeResources(), prevNode.TraderActiveOffers, prevNode.MemoryUsed)
+		res.NodesMetrics[index].SetCapacity(prevNode.CurrentCapacity())
+		res.NodesMetrics[index].SetArcLength(prevNode.RingArcLength())
 	}
 
 	return res
@@ -80,8 +109,11 @@ func NewGlobalNext(numNodes int, prevGlobal *Global) *Global {
 
 // ========================= Metrics Collector Methods ====================================
 
-func (g *Global) MessageReceived(nodeIndex int, amount int64, requestSizeBytes int64) {
-	g.NodesMetrics[nodeIndex].MessageReceived(amount, requestSizeBytes)
+func (g *Global) MessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
+	g.NodesMetrics[toNodeIndex].MessageReceived(msgType, amount, requestSizeBytes)
+	g.NodesMetrics[fromNodeIndex].MessageSent(amount, requestSizeBytes)
+	atomic.AddInt64(&g.MessagesByType.Amount[msgType], amount)
+	atomic.AddInt64(&g.MessagesByType.Size[msgType], requestSizeBytes)
 }
 
 func (g *Global) GetOfferRelayed(amount int64) {
@@ -92,8 +124,8 @@ func (g *Global) EmptyGetOfferMessages(amount int64) {
 	atomic.AddInt64(&g.EmptyGetOffersMessages, amount)
 }
 
-func (g *Global) CreateRunRequest(nodeIndex int, requestID string, resources types.Resources) {
-	newRunRequest := NewRunRequest(resources)
+func (g *Global) CreateRunRequest(nodeIndex int, requestID string, source string, tenant string, resources types.Resources) {
+	newRunRequest := NewRunRequest(source, tenant, resources)
 	newRunRequest.IncrMessagesExchanged(1)
 	g.RunRequestsAggregator.Store(requestID, newRunRequest)
 
@@ -111,6 +143,88 @@ func (g *Global) IncrMessagesTradedRequest(requestID string, numMessages int) {
 	}
 }
 
+func (g *Global) SetNodesArcLength(arcLengths []float64) {
+	for index := range g.NodesMetrics {
+		if index < len(arcLengths) {
+			g.NodesMetrics[index].SetArcLength(arcLengths[index])
+		}
+	}
+}
+
+func (g *Global) LookupMessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
+	g.MessageReceived(fromNodeIndex, toNodeIndex, msgType, amount, requestSizeBytes)
+	g.NodesMetrics[toNodeIndex].LookupMessageReceived(amount)
+}
+
+func (g *Global) LookupDone(fromNodeIndex int, path []int, latency float64, sampled bool) {
+	g.lookupsMutex.Lock()
+	defer g.lookupsMutex.Unlock()
+
+	for len(g.LookupsHops) <= len(path) {
+		g.LookupsHops = append(g.LookupsHops, 0)
+	}
+	g.LookupsHops[len(path)]++
+
+	latencyBin := int(latency / lookupLatencyBinSize)
+	for len(g.LookupsLatencies) <= latencyBin {
+		g.LookupsLatencies = append(g.LookupsLatencies, 0)
+	}
+	g.LookupsLatencies[latencyBin]++
+	g.LookupsLatencySum += latency
+	if latency > g.LookupsLatencyMax {
+		g.LookupsLatencyMax = latency
+	}
+
+	if sampled {
+		g.LookupPaths = append(g.LookupPaths, LookupPath{From: fromNodeIndex, Nodes: append([]int(nil), path...),
+			Latency: latency})
+	}
+}
+
+func (g *Global) MaintenanceMessageReceived(fromNodeIndex, toNodeIndex int, msgType MessageType, amount int64, requestSizeBytes int64) {
+	g.MessageReceived(fromNodeIndex, toNodeIndex, msgType, amount, requestSizeBytes)
+	g.NodesMetrics[toNodeIndex].MaintenanceMessageReceived(amount)
+	atomic.AddInt64(&g.OverlayMaintenanceMsgs, amount)
+}
+
+func (g *Global) IncrOverlayStaleLookups() {
+	atomic.AddInt64(&g.OverlayStaleLookups, 1)
+}
+
+func (g *Global) CapacityChanged(nodeIndex int, capacity types.Resources) {
+	atomic.AddInt64(&g.CapacityChanges, 1)
+	g.NodesMetrics[nodeIndex].SetCapacity(capacity)
+}
+
+func (g *Global) IncrContainersEvicted(amount int64) {
+	atomic.AddInt64(&g.ContainersEvicted, amount)
+}
+
+func (g *Global) IncrContainersEnded(finished, crashed, oomKilled int64) {
+	atomic.AddInt64(&g.ContainersFinished, finished)
+	atomic.AddInt64(&g.ContainersCrashed, crashed)
+	atomic.AddInt64(&g.ContainersOOMKilled, oomKilled)
+}
+
+func (g *Global) ImageRequested(nodeIndex int, cacheHit bool, pulledBytes int64, pullTime time.Duration) {
+	atomic.AddInt64(&g.ImageRequests, 1)
+	if cacheHit {
+		atomic.AddInt64(&g.ImageCacheHits, 1)
+		return
+	}
+	atomic.AddInt64(&g.ImagePullsSize, pulledBytes)
+	atomic.AddInt64((*int64)(&g.ImagePullsTime), int64(pullTime))
+	g.NodesMetrics[nodeIndex].ImagePulled(pulledBytes)
+}
+
+func (g *Global) ContainerLaunched(requestID string, pullTime time.Duration) {
+	if req, exist := g.RunRequestsAggregator.Load(requestID); exist {
+		if request, ok := req.(*RunRequest); ok {
+			request.IncrLatency(pullTime)
+		}
+	}
+}
+
 func (g *Global) ArchiveRunRequest(requestID string, succeeded bool) {
 	if req, exist := g.RunRequestsAggregator.Load(requestID); exist {
 		if request, ok := req.(*RunRequest); ok {
@@ -120,6 +234,7 @@ func (g *Global) ArchiveRunRequest(requestID string, succeeded bool) {
 				atomic.AddInt64(&g.RunRequestsSucceeded, 1)
 			}
 
+			request.Succeeded = succeeded
 			g.RunRequestsAggregator.Delete(requestID)
 
 			g.requestsCompletedMutex.Lock()
@@ -139,6 +254,18 @@ func (g *Global) RunRequestSuccessRatio() float64 {
 	return float64(g.RunRequestsSucceeded) / float64(g.TotalRunRequests())
 }
 
+// TotalRunRequestsLatency returns the sum of the latencies of the requests succeeded and their number.
+func (g *Global) TotalRunRequestsLatency() (time.Duration, int64) {
+	accLatency, succeeded := time.Duration(0), int64(0)
+	for _, runRequest := range g.RunRequestsCompleted {
+		if runRequest.HasSucceeded() {
+			accLatency += runRequest.TotalLatency()
+			succeeded++
+		}
+	}
+	return accLatency, succeeded
+}
+
 func (g *Global) RunRequestsAvgMessages() float64 {
 	if len(g.RunRequestsCompleted) == 0 {
 		return 0
@@ -164,6 +291,22 @@ func (g *Global) TotalUsedResourcesAvg() float64 {
 	return result
 }
 
+// SystemUsedResourcesRatio returns the ratio of the system's resources, currently provided by the nodes' owners,
+// that are being used.
+func (g *Global) SystemUsedResourcesRatio() float64 {
+	maxCPUs, maxMemory, usedCPUs, usedMemory := int64(0), int64(0), int64(0), int64(0)
+	for index := range g.NodesMetrics {
+		maxCPUs += int64(g.NodesMetrics[index].CurrentCapacity().CPUs)
+		maxMemory += int64(g.NodesMetrics[index].CurrentCapacity().Memory)
+		usedCPUs += int64(g.NodesMetrics[index].UsedResources().CPUs)
+		usedMemory += int64(g.NodesMetrics[index].UsedResources().Memory)
+	}
+	if maxCPUs == 0 || maxMemory == 0 {
+		return 0
+	}
+	return (float64(usedCPUs)/float64(maxCPUs) + float64(usedMemory)/float64(maxMemory)) / 2
+}
+
 func (g *Global) TotalFreeResourcesAvg() float64 {
 	result := float64(0)
 	numOfNodesCalculated := float64(0)
@@ -226,6 +369,28 @@ func (g *Global) TotalBandwidthUsedOnReceivingByNode() []float64 {
 	return res
 }
 
+// MessagesReceivedByType returns the number and the size (bytes) of the messages received of the type.
+func (g *Global) MessagesReceivedByType(msgType MessageType) (int64, int64) {
+	return g.MessagesByType.Amount[msgType], g.MessagesByType.Size[msgType]
+}
+
+// TotalBandwidthUsedOnReceiving returns the bytes received by all the nodes.
+func (g *Global) TotalBandwidthUsedOnReceiving() float64 {
+	res := 0.0
+	for i := range g.NodesMetrics {
+		res += g.NodesMetrics[i].TotalBandwidthUsedOnReceiving()
+	}
+	return res
+}
+
+func (g *Global) TotalBandwidthUsedOnSendingByNode() []float64 {
+	res := make([]float64, len(g.NodesMetrics))
+	for i, nodeMetric := range g.NodesMetrics {
+		res[i] = float64(nodeMetric.TotalBandwidthUsedOnSending())
+	}
+	return res
+}
+
 func (g *Global) TotalMessagesReceivedByNode() []float64 {
 	res := make([]float64, len(g.NodesMetrics))
 	for i, nodeMetric := range g.NodesMetrics {
@@ -234,6 +399,14 @@ func (g *Global) TotalMessagesReceivedByNode() []float64 {
 	return res
 }
 
+func (g *Global) ArcLengthByNode() []float64 {
+	res := make([]float64, len(g.NodesMetrics))
+	for i, nodeMetric := range g.NodesMetrics {
+		res[i] = nodeMetric.RingArcLength()
+	}
+	return res
+}
+
 func (g *Global) TotalMessagesReceivedByAllNodes() float64 {
 	acc := float64(0)
 	for _, nodeMetric := range g.NodesMetrics {
@@ -285,6 +458,61 @@ func (g *Global) TotalRunRequestsSucceeded() int64 {
 	return g.RunRequestsSucceeded
 }
 
+func (g *Global) TotalOverlayMaintenanceMsgs() int64 {
+	return g.OverlayMaintenanceMsgs
+}
+
+func (g *Global) TotalOverlayStaleLookups() int64 {
+	return g.OverlayStaleLookups
+}
+
+func (g *Global) TotalCapacityChanges() int64 {
+	return g.CapacityChanges
+}
+
+func (g *Global) TotalContainersEvicted() int64 {
+	return g.ContainersEvicted
+}
+
+// TotalImageRequests returns the number of images needed by the containers and the number of them that were
+// found in the nodes' image caches.
+func (g *Global) TotalImageRequests() (int64, int64) {
+	return g.ImageRequests, g.ImageCacheHits
+}
+
+// TotalImagePulls returns the bytes pulled from the registry and the time spent pulling them.
+func (g *Global) TotalImagePulls() (int64, time.Duration) {
+	return g.ImagePullsSize, g.ImagePullsTime
+}
+
+func (g *Global) TotalImagesPulledSizeByNode() []float64 {
+	res := make([]float64, len(g.NodesMetrics))
+	for i, nodeMetric := range g.NodesMetrics {
+		res[i] = nodeMetric.TotalImagesPulledSize()
+	}
+	return res
+}
+
+// TotalContainersEnded returns the number of containers that finished, crashed and were OOM-killed.
+func (g *Global) TotalContainersEnded() (int64, int64, int64) {
+	return g.ContainersFinished, g.ContainersCrashed, g.ContainersOOMKilled
+}
+
+// SystemCapacityRatio returns the ratio of the system's maximum resources currently provided by the nodes' owners.
+func (g *Global) SystemCapacityRatio() float64 {
+	maxCPUs, maxMemory, capacityCPUs, capacityMemory := 0, 0, 0, 0
+	for index := range g.NodesMetrics {
+		maxCPUs += g.NodesMetrics[index].MaximumResources().CPUs
+		maxMemory += g.NodesMetrics[index].MaximumResources().Memory
+		capacityCPUs += g.NodesMetrics[index].CurrentCapacity().CPUs
+		capacityMemory += g.NodesMetrics[index].CurrentCapacity().Memory
+	}
+	if maxCPUs == 0 || maxMemory == 0 {
+		return 0
+	}
+	return (float64(capacityCPUs)/float64(maxCPUs) + float64(capacityMemory)/float64(maxMemory)) / 2
+}
+
 func (g *Global) TotalRunRequests() int64 {
 	return int64(len(g.RunRequestsCompleted))
 }
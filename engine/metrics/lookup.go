@@ -0,0 +1,103 @@
+package metrics
+
+// lookupLatencyBinSize size (ms) of the bins of the lookups' latencies histogram.
+const lookupLatencyBinSize = 10.0
+
+// LookupPath represents the path of an overlay's lookup that was sampled.
+type LookupPath struct {
+	From    int     `json:"From"`    // Index of the node that did the lookup.
+	Nodes   []int   `json:"Nodes"`   // Indexes of the nodes that received the lookup's requests, in order.
+	Latency float64 `json:"Latency"` // Latency (ms) of the lookup, including the reply to the node that did it.
+}
+
+// lookupsHops returns the number of lookups done with each number of hops during the whole simulation.
+func (sim *simulationData) lookupsHops() []int64 {
+	res := make([]int64, 0)
+	for i := range sim.snapshots {
+		for hops, lookups := range sim.snapshots[i].LookupsHops {
+			for len(res) <= hops {
+				res = append(res, 0)
+			}
+			res[hops] += lookups
+		}
+	}
+	return res
+}
+
+// lookupsLatencies returns the number of lookups done in each latency's bin during the whole simulation.
+func (sim *simulationData) lookupsLatencies() []int64 {
+	res := make([]int64, 0)
+	for i := range sim.snapshots {
+		for bin, lookups := range sim.snapshots[i].LookupsLatencies {
+			for len(res) <= bin {
+				res = append(res, 0)
+			}
+			res[bin] += lookups
+		}
+	}
+	return res
+}
+
+// lookupsLatency returns the average latency and the maximum latency (ms) of the lookups done during the whole
+// simulation.
+func (sim *simulationData) lookupsLatency() (float64, float64) {
+	lookups, latencyAcc, maxLatency := int64(0), 0.0, 0.0
+	for i := range sim.snapshots {
+		for _, amount := range sim.snapshots[i].LookupsLatencies {
+			lookups += amount
+		}
+		latencyAcc += sim.snapshots[i].LookupsLatencySum
+		if sim.snapshots[i].LookupsLatencyMax > maxLatency {
+			maxLatency = sim.snapshots[i].LookupsLatencyMax
+		}
+	}
+	if lookups == 0 {
+		return 0, 0
+	}
+	return latencyAcc / float64(lookups), maxLatency
+}
+
+// lookupsPaths returns the lookups' paths sampled during the whole simulation.
+func (sim *simulationData) lookupsPaths() []LookupPath {
+	res := make([]LookupPath, 0)
+	for i := range sim.snapshots {
+		res = append(res, sim.snapshots[i].LookupPaths...)
+	}
+	return res
+}
+
+// relayMessages returns the lookup's messages and the other (application) messages received by each node
+// during the whole simulation.
+func (sim *simulationData) relayMessages() ([]float64, []float64) {
+	if len(sim.snapshots) == 0 {
+		return nil, nil
+	}
+
+	lookupMessages := make([]float64, len(sim.snapshots[0].NodesMetrics))
+	appMessages := make([]float64, len(sim.snapshots[0].NodesMetrics))
+	for i := range sim.snapshots {
+		for node := range sim.snapshots[i].NodesMetrics {
+			if node < len(lookupMessages) {
+				lookupMessages[node] += sim.snapshots[i].NodesMetrics[node].TotalLookupMessagesReceived()
+				appMessages[node] += sim.snapshots[i].NodesMetrics[node].TotalApplicationMessagesReceived()
+			}
+		}
+	}
+	return lookupMessages, appMessages
+}
+
+// hopsAverage returns the average number of hops and the maximum number of hops of the lookups.
+func hopsAverage(lookupsHops []int64) (float64, int) {
+	lookups, hopsAcc, maxHops := int64(0), int64(0), 0
+	for hops, amount := range lookupsHops {
+		lookups += amount
+		hopsAcc += int64(hops) * amount
+		if amount > 0 {
+			maxHops = hops
+		}
+	}
+	if lookups == 0 {
+		return 0, 0
+	}
+	return float64(hopsAcc) / float64(lookups), maxHops
+}
@@ -0,0 +1,61 @@
+package metrics
+
+// MessageType identifies the type of the messages traded between the nodes (the responses are accounted in
+// the type of their request).
+type MessageType int
+
+const (
+	CreateOfferMsg MessageType = iota
+	RefreshOfferMsg
+	UpdateOfferMsg
+	RemoveOfferMsg
+	GetOffersMsg
+	AdvertiseOffersNeighborMsg
+	LaunchContainerMsg
+	StopLocalContainerMsg
+	FindSuccessorMsg // Chord's lookups (and the ones to fix the fingers).
+	StabilizeMsg     // Chord's stabilization.
+	NotifyMsg        // Chord's notifications of the nodes joining/leaving the ring.
+	FindNodeMsg      // Kademlia's lookups.
+	numMessageTypes  // Number of message's types (must be the last one).
+)
+
+// messageTypesNames holds the name of each message's type.
+var messageTypesNames = [numMessageTypes]string{
+	"CreateOffer",
+	"RefreshOffer",
+	"UpdateOffer",
+	"RemoveOffer",
+	"GetOffers",
+	"AdvertiseOffersNeighbor",
+	"LaunchContainer",
+	"StopLocalContainer",
+	"FindSuccessor",
+	"Stabilize",
+	"Notify",
+	"FindNode",
+}
+
+func (t MessageType) String() string {
+	return messageTypesNames[t]
+}
+
+// MessagesByType holds the number and the size of the messages received of each type.
+type MessagesByType struct {
+	Amount [numMessageTypes]int64 `json:"Amount"` // Number of messages received of each type.
+	Size   [numMessageTypes]int64 `json:"Size"`   // Total size (bytes) of the messages received of each type.
+}
+
+// messagesByType returns the number and the size of the messages received of each type during the whole
+// simulation.
+func (sim *simulationData) messagesByType() MessagesByType {
+	res := MessagesByType{}
+	for i := range sim.snapshots {
+		for msgType := MessageType(0); msgType < numMessageTypes; msgType++ {
+			amount, size := sim.snapshots[i].MessagesReceivedByType(msgType)
+			res.Amount[msgType] += amount
+			res.Size[msgType] += size
+		}
+	}
+	return res
+}
@@ -7,22 +7,33 @@ import (
 
 // Node represents a node in the system and it is used to collect node's level metrics of a CARAVELA's node.
 type Node struct {
-	MaxResources         types.Resources `json:"MaxResources"`         // Maximum resources available in the node.
-	FreeResource         types.Resources `json:"FreeResources"`        // Current available resources in the node.
-	MessagesReceived     int64           `json:"MessagesReceived"`     // Number of API requests received.
-	MessagesReceivedSize int64           `json:"MessagesReceivedSize"` // Total size of all received messages API + Chord.
-	MemoryUsed           int64           `json:"MemoryUsed"`           // Total memory occupied by the Caravela's logic components.
-	RequestsSubmitted    int64           `json:"RequestsSubmitted"`    // Number of requests submitted in the node.
-	TraderActiveOffers   int64           `json:"TraderActiveOffers"`   // Number of active offers in the node.
+	MaxResources            types.Resources `json:"MaxResources"`            // Maximum resources available in the node.
+	Capacity                types.Resources `json:"Capacity"`                // Resources that the node's owner currently provides.
+	FreeResource            types.Resources `json:"FreeResources"`           // Current available resources in the node.
+	MessagesReceived        int64           `json:"MessagesReceived"`        // Number of API requests received.
+	MessagesReceivedSize    int64           `json:"MessagesReceivedSize"`    // Total size of all received messages API + Chord.
+	MessagesSent            int64           `json:"MessagesSent"`            // Number of messages (requests and responses) sent.
+	MessagesSentSize        int64           `json:"MessagesSentSize"`        // Total size of all sent messages API + Chord.
+	LookupMsgsReceived      int64           `json:"LookupMsgsReceived"`      // Number of overlay's lookup messages received.
+	MaintenanceMsgsReceived int64           `json:"MaintenanceMsgsReceived"` // Number of overlay's maintenance messages received.
+	MessagesByType          MessagesByType  `json:"MessagesByType"`          // Number/size of the messages received of each type.
+	ImagesPulledSize        int64           `json:"ImagesPulledSize"`        // Total size of the images pulled from the registry.
+	MemoryUsed              int64           `json:"MemoryUsed"`              // Total memory occupied by the Caravela's logic components.
+	RequestsSubmitted       int64           `json:"RequestsSubmitted"`       // Number of requests submitted in the node.
+	TraderActiveOffers      int64           `json:"TraderActiveOffers"`      // Number of active offers in the node.
+	ArcLength               float64         `json:"ArcLength"`               // Fraction of the chord's ring owned by the node.
 }
 
 // NewNode creates a new structure of to hold a node's metrics.
 func NewNode(maxResources types.Resources) *Node {
 	return &Node{
 		MaxResources:         maxResources,
+		Capacity:             maxResources,
 		FreeResource:         maxResources,
 		MessagesReceived:     0,
 		MessagesReceivedSize: 0,
+		MessagesSent:         0,
+		MessagesSentSize:     0,
 		RequestsSubmitted:    0,
 		MemoryUsed:           0,
 		TraderActiveOffers:   0,
@@ -31,9 +42,28 @@ func NewNode(maxResources types.Resources) *Node {
 
 // ========================= Metrics Collector Methods ====================================
 
-func (n *Node) MessageReceived(amountMessages int64, requestSizeBytes int64) {
+func (n *Node) MessageReceived(msgType MessageType, amountMessages int64, requestSizeBytes int64) {
 	atomic.AddInt64(&n.MessagesReceived, amountMessages)
 	atomic.AddInt64(&n.MessagesReceivedSize, requestSizeBytes)
+	atomic.AddInt64(&n.MessagesByType.Amount[msgType], amountMessages)
+	atomic.AddInt64(&n.MessagesByType.Size[msgType], requestSizeBytes)
+}
+
+func (n *Node) MessageSent(amountMessages int64, requestSizeBytes int64) {
+	atomic.AddInt64(&n.MessagesSent, amountMessages)
+	atomic.AddInt64(&n.MessagesSentSize, requestSizeBytes)
+}
+
+func (n *Node) ImagePulled(sizeBytes int64) {
+	atomic.AddInt64(&n.ImagesPulledSize, sizeBytes)
+}
+
+func (n *Node) LookupMessageReceived(amountMessages int64) {
+	atomic.AddInt64(&n.LookupMsgsReceived, amountMessages)
+}
+
+func (n *Node) MaintenanceMessageReceived(amountMessages int64) {
+	atomic.AddInt64(&n.MaintenanceMsgsReceived, amountMessages)
 }
 
 func (n *Node) RunRequestSubmitted() {
@@ -46,8 +76,24 @@ func (n *Node) SetNodeState(freeResources types.Resources, traderActiveOffers in
 	n.MemoryUsed = memoryUsed
 }
 
+func (n *Node) SetCapacity(capacity types.Resources) {
+	n.Capacity = capacity
+}
+
+func (n *Node) SetArcLength(arcLength float64) {
+	n.ArcLength = arcLength
+}
+
 // ================================== Getters  =============================================
 
+func (n *Node) CurrentCapacity() types.Resources {
+	return n.Capacity
+}
+
+func (n *Node) RingArcLength() float64 {
+	return n.ArcLength
+}
+
 func (n *Node) MaximumResources() types.Resources {
 	return n.MaxResources
 }
@@ -58,41 +104,53 @@ func (n *Node) FreeResources() types.Resources {
 
 func (n *Node) UsedResources() types.Resources {
 	return types.Resources{
-		CPUs:   n.MaxResources.CPUs - n.FreeResource.CPUs,
-		Memory: n.MaxResources.Memory - n.FreeResource.Memory,
+		CPUs:   n.Capacity.CPUs - n.FreeResource.CPUs,
+		Memory: n.Capacity.Memory - n.FreeResource.Memory,
 	}
 }
 func (n *Node) FreeResourcesRatio() float64 {
 	if n.FreeResource.CPUs == 0 || n.FreeResource.Memory == 0 { // Impossible use this "free" resources.
 		return 0
 	}
-	cpusRatio := float64(n.FreeResource.CPUs) / float64(n.MaxResources.CPUs)
-	memoryRatio := float64(n.FreeResource.Memory) / float64(n.MaxResources.Memory)
-	return (cpusRatio + memoryRatio) / 2
+	return n.capacityRatio(n.FreeResource)
 }
 
 func (n *Node) UsedResourcesRatio() float64 {
 	if n.FreeResource.CPUs == 0 || n.FreeResource.Memory == 0 { // Impossible use this "free" resources.
 		return float64(1)
 	}
-	cpusRatio := float64(n.UsedResources().CPUs) / float64(n.MaxResources.CPUs)
-	memoryRatio := float64(n.UsedResources().Memory) / float64(n.MaxResources.Memory)
-	return (cpusRatio + memoryRatio) / 2
+	return n.capacityRatio(n.UsedResources())
 }
 
 func (n *Node) UnreachableResourcesRatio() float64 {
 	if n.FreeResource.CPUs == 0 || n.FreeResource.Memory == 0 { // Impossible use this "free" resources.
-		cpusRatio := float64(n.FreeResource.CPUs) / float64(n.MaxResources.CPUs)
-		memoryRatio := float64(n.FreeResource.Memory) / float64(n.MaxResources.Memory)
-		return (cpusRatio + memoryRatio) / 2
+		return n.capacityRatio(n.FreeResource)
 	}
 	return 0
 }
 
+// capacityRatio returns the ratio of the node's current capacity that the given resources represent.
+func (n *Node) capacityRatio(resources types.Resources) float64 {
+	if n.Capacity.CPUs == 0 || n.Capacity.Memory == 0 { // The owner reclaimed all the node's resources.
+		return 0
+	}
+	cpusRatio := float64(resources.CPUs) / float64(n.Capacity.CPUs)
+	memoryRatio := float64(resources.Memory) / float64(n.Capacity.Memory)
+	return (cpusRatio + memoryRatio) / 2
+}
+
 func (n *Node) TotalBandwidthUsedOnReceiving() float64 {
 	return float64(n.MessagesReceivedSize)
 }
 
+func (n *Node) TotalBandwidthUsedOnSending() float64 {
+	return float64(n.MessagesSentSize)
+}
+
+func (n *Node) TotalImagesPulledSize() float64 {
+	return float64(n.ImagesPulledSize)
+}
+
 func (n *Node) TotalMemoryUsed() float64 {
 	return float64(n.MemoryUsed)
 }
@@ -101,6 +159,29 @@ func (n *Node) TotalMessagesReceived() float64 {
 	return float64(n.MessagesReceived)
 }
 
+// MessagesReceivedByType returns the number and the size (bytes) of the messages received of the type.
+func (n *Node) MessagesReceivedByType(msgType MessageType) (int64, int64) {
+	return n.MessagesByType.Amount[msgType], n.MessagesByType.Size[msgType]
+}
+
+func (n *Node) TotalMessagesSent() float64 {
+	return float64(n.MessagesSent)
+}
+
+func (n *Node) TotalLookupMessagesReceived() float64 {
+	return float64(n.LookupMsgsReceived)
+}
+
+func (n *Node) TotalMaintenanceMessagesReceived() float64 {
+	return float64(n.MaintenanceMsgsReceived)
+}
+
+// TotalApplicationMessagesReceived returns the messages received that weren't part of an overlay's lookup or
+// of the overlay's maintenance.
+func (n *Node) TotalApplicationMessagesReceived() float64 {
+	return float64(n.MessagesReceived - n.LookupMsgsReceived - n.MaintenanceMsgsReceived)
+}
+
 func (n *Node) TotalRunRequestsSubmitted() float64 {
 	return float64(n.RequestsSubmitted)
 }
@@ -3,20 +3,29 @@ package metrics
 import (
 	"github.com/strabox/caravela/api/types"
 	"sync/atomic"
+	"time"
 )
 
 // RunRequest represents a request, to deploy a container, that was submitted in the system.
 // It collects request level's metrics for the request.
 type RunRequest struct {
+	Source         string          `json:"Source"`         // Workload source that generated the request.
+	Tenant         string          `json:"Tenant"`         // Tenant (user) that submitted the request.
 	ResRequested   types.Resources `json:"ResRequested"`   // ResRequested necessary for the container.
 	MessagesTraded int64           `json:"MessagesTraded"` // Messages traded in the system to handle the request.
+	Latency        time.Duration   `json:"Latency"`        // Time until the request's containers start (images' pulls).
+	Succeeded      bool            `json:"Succeeded"`      // True if the request was deployed with success.
 }
 
 // NewRunRequest creates a new structure to hold the information about a request.
-func NewRunRequest(resourcesRequested types.Resources) *RunRequest {
+func NewRunRequest(source string, tenant string, resourcesRequested types.Resources) *RunRequest {
 	return &RunRequest{
+		Source:         source,
+		Tenant:         tenant,
 		ResRequested:   resourcesRequested,
 		MessagesTraded: 0,
+		Latency:        0,
+		Succeeded:      false,
 	}
 }
 
@@ -27,6 +36,11 @@ func (r *RunRequest) IncrMessagesExchanged(numMessages int64) {
 	atomic.AddInt64(&r.MessagesTraded, numMessages)
 }
 
+// IncrLatency increments the time until the request's containers start.
+func (r *RunRequest) IncrLatency(latency time.Duration) {
+	atomic.AddInt64((*int64)(&r.Latency), int64(latency))
+}
+
 // ============================ Getters and Setters ========================================
 
 // TotalMessagesExchanged returns the total number of messages necessary to handle the request.
@@ -34,6 +48,26 @@ func (r *RunRequest) TotalMessagesExchanged() int64 {
 	return r.MessagesTraded
 }
 
+// TotalLatency returns the time until the request's containers start.
+func (r *RunRequest) TotalLatency() time.Duration {
+	return r.Latency
+}
+
 func (r *RunRequest) ResourcesRequested() types.Resources {
 	return r.ResRequested
 }
+
+// RequestSource returns the workload source that generated the request.
+func (r *RunRequest) RequestSource() string {
+	return r.Source
+}
+
+// RequestTenant returns the tenant that submitted the request.
+func (r *RunRequest) RequestTenant() string {
+	return r.Tenant
+}
+
+// HasSucceeded returns true if the request was deployed with success.
+func (r *RunRequest) HasSucceeded() bool {
+	return r.Succeeded
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// requestsGroup aggregates the metrics of a group of requests (e.g. the requests of a workload source or tenant).
+type requestsGroup struct {
+	name               string    // Name of the group.
+	requests           int64     // Number of requests in the group.
+	requestsSucceeded  int64     // Number of requests, of the group, that were deployed with success.
+	messagesTraded     int64     // Messages traded in the system to handle the group's requests.
+	resourcesAllocated Resources // Resources allocated to the group's requests that succeeded.
+}
+
+// SuccessRatio returns the success ratio of the group's requests.
+func (r *requestsGroup) SuccessRatio() float64 {
+	if r.requests == 0 {
+		return 0
+	}
+	return float64(r.requestsSucceeded) / float64(r.requests)
+}
+
+// AvgMessagesPerRequest returns the average number of messages traded to handle a group's request.
+func (r *requestsGroup) AvgMessagesPerRequest() float64 {
+	if r.requests == 0 {
+		return 0
+	}
+	return float64(r.messagesTraded) / float64(r.requests)
+}
+
+// groupRequests aggregates the completed requests of the simulation by the group key of each request.
+// The result is sorted by the groups name.
+func (sim *simulationData) groupRequests(groupKey func(request *RunRequest) string) []*requestsGroup {
+	groups := make(map[string]*requestsGroup)
+	for i := range sim.snapshots {
+		for j := range sim.snapshots[i].RunRequestsCompleted {
+			request := &sim.snapshots[i].RunRequestsCompleted[j]
+			key := groupKey(request)
+			group, exist := groups[key]
+			if !exist {
+				group = &requestsGroup{name: key}
+				groups[key] = group
+			}
+			group.requests++
+			group.messagesTraded += request.TotalMessagesExchanged()
+			if request.HasSucceeded() {
+				group.requestsSucceeded++
+				group.resourcesAllocated.CPUs += int64(request.ResourcesRequested().CPUs)
+				group.resourcesAllocated.Memory += int64(request.ResourcesRequested().Memory)
+			}
+		}
+	}
+
+	res := make([]*requestsGroup, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, group)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res
+}
+
+// requestsPerSource aggregates the completed requests of the simulation by the workload source that generated them.
+func (sim *simulationData) requestsPerSource() []*requestsGroup {
+	return sim.groupRequests((*RunRequest).RequestSource)
+}
+
+// requestsPerTenant aggregates the completed requests of the simulation by the tenant that submitted them.
+func (sim *simulationData) requestsPerTenant() []*requestsGroup {
+	return sim.groupRequests((*RunRequest).RequestTenant)
+}
+
+// jainFairnessIndex returns the Jain's fairness index of the given values, 1 means that all are equal.
+func jainFairnessIndex(values []float64) float64 {
+	sum, sumSquares := float64(0), float64(0)
+	for _, value := range values {
+		sum += value
+		sumSquares += value * value
+	}
+	if sumSquares == 0 {
+		return 1
+	}
+	return math.Pow(sum, 2) / (float64(len(values)) * sumSquares)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// ringArcsLoad returns the fraction of the chord's ring owned by each node and the messages received by each
+// node during the whole simulation.
+func (sim *simulationData) ringArcsLoad() ([]float64, []float64) {
+	if len(sim.snapshots) == 0 {
+		return nil, nil
+	}
+
+	arcLengths := sim.snapshots[len(sim.snapshots)-1].ArcLengthByNode()
+	messagesReceived := make([]float64, len(arcLengths))
+	for i := range sim.snapshots {
+		for node, messages := range sim.snapshots[i].TotalMessagesReceivedByNode() {
+			if node < len(messagesReceived) {
+				messagesReceived[node] += messages
+			}
+		}
+	}
+	return arcLengths, messagesReceived
+}
+
+// maxMeanRatio returns the ratio between the maximum and the mean of the given values.
+func maxMeanRatio(values []float64) float64 {
+	sum, max := float64(0), float64(0)
+	for _, value := range values {
+		sum += value
+		max = math.Max(max, value)
+	}
+	if sum == 0 {
+		return 0
+	}
+	return max / (sum / float64(len(values)))
+}
+
+// giniCoefficient returns the Gini coefficient of the given values, 0 means that all are equal.
+func giniCoefficient(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum, weightedSum := float64(0), float64(0)
+	for i, value := range sorted {
+		sum += value
+		weightedSum += float64(i+1) * value
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return (2*weightedSum)/(n*sum) - (n+1)/n
+}
+
+// pearsonCorrelation returns the correlation between the two series of values (0 if one of them is constant).
+func pearsonCorrelation(x, y []float64) float64 {
+	n := float64(len(x))
+	if n == 0 || len(x) != len(y) {
+		return 0
+	}
+
+	meanX, meanY := float64(0), float64(0)
+	for i := range x {
+		meanX += x[i] / n
+		meanY += y[i] / n
+	}
+	covariance, varianceX, varianceY := float64(0), float64(0), float64(0)
+	for i := range x {
+		covariance += (x[i] - meanX) * (y[i] - meanY)
+		varianceX += (x[i] - meanX) * (x[i] - meanX)
+		varianceY += (y[i] - meanY) * (y[i] - meanY)
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
@@ -3,23 +3,32 @@ package caravela
 import (
 	"context"
 	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/mocks/wire"
 	"github.com/strabox/caravela/api/rest/util"
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
+	"sync"
 )
 
 // RemoteClientMock mocks the remote calls from a node to another via the simulator.
 type RemoteClientMock struct {
 	nodeService simNodeService     // Obtains nodes to send messages
+	sizes       wire.SizeModel     // Measures the size of the messages
 	collector   *metrics.Collector // Collects metrics
+
+	suppliersOffers sync.Map // Map of Supplier's IP<->*supplierOffers, the offers of each supplier.
 }
 
 // NewRemoteClientMock creates a new mock for the inter-node interactions.
 // It implements the github.com/strabox/caravela/node/external Caravela interface.
-func NewRemoteClientMock(nodeService simNodeService, metricsCollector *metrics.Collector) *RemoteClientMock {
+func NewRemoteClientMock(nodeService simNodeService, sizeModel wire.SizeModel,
+	metricsCollector *metrics.Collector) *RemoteClientMock {
 	return &RemoteClientMock{
 		nodeService: nodeService,
+		sizes:       sizeModel,
 		collector:   metricsCollector,
+
+		suppliersOffers: sync.Map{},
 	}
 }
 
@@ -32,13 +41,14 @@ func (r *RemoteClientMock) CreateOffer(ctx context.Context, fromSupp *types.Node
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	messageSize := sizeofCreateOfferMessage(&util.CreateOfferMsg{ToNode: *toTrader, FromNode: *fromSupp, Offer: *offer})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(messageSize))
+	messageSize := sizeofCreateOfferMessage(r.sizes, &util.CreateOfferMsg{ToNode: *toTrader, FromNode: *fromSupp, Offer: *offer})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.CreateOfferMsg, 1, int64(messageSize))
 
 	toNode.CreateOffer(ctx, fromSupp, toTrader, offer)
+	r.offerCreated(fromSupp.IP, offer)
 
 	// Collect Metrics (fromNode)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(8))
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.CreateOfferMsg, 1, int64(sizeofAckMessage(r.sizes)))
 
 	return nil
 }
@@ -48,14 +58,14 @@ func (r *RemoteClientMock) RefreshOffer(ctx context.Context, fromTrader, toSupp
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	toMessageSize := sizeofRefreshOfferMessage(&util.RefreshOfferMsg{FromTrader: *fromTrader, Offer: *offer})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(toMessageSize))
+	toMessageSize := sizeofRefreshOfferMessage(r.sizes, &util.RefreshOfferMsg{FromTrader: *fromTrader, Offer: *offer})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.RefreshOfferMsg, 1, int64(toMessageSize))
 
 	response := toNode.RefreshOffer(ctx, fromTrader, offer)
 
 	// Collect Metrics (fromNode)
-	fromMessageSize := sizeofRefreshOfferMessageResponse(&util.RefreshOfferResponseMsg{Refreshed: response})
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(fromMessageSize))
+	fromMessageSize := sizeofRefreshOfferMessageResponse(r.sizes, &util.RefreshOfferResponseMsg{Refreshed: response})
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.RefreshOfferMsg, 1, int64(fromMessageSize))
 
 	return response, nil
 }
@@ -65,13 +75,13 @@ func (r *RemoteClientMock) UpdateOffer(ctx context.Context, fromSupplier, toTrad
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	messageSize := sizeofUpdateOfferMessage(&util.UpdateOfferMsg{FromSupplier: *fromSupplier, ToTrader: *toTrader, Offer: *offer})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(messageSize))
+	messageSize := sizeofUpdateOfferMessage(r.sizes, &util.UpdateOfferMsg{FromSupplier: *fromSupplier, ToTrader: *toTrader, Offer: *offer})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.UpdateOfferMsg, 1, int64(messageSize))
 
 	toNode.UpdateOffer(ctx, fromSupplier, toTrader, offer)
 
 	// Collect Metrics (fromNode)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(8))
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.UpdateOfferMsg, 1, int64(sizeofAckMessage(r.sizes)))
 
 	return nil
 }
@@ -81,13 +91,14 @@ func (r *RemoteClientMock) RemoveOffer(ctx context.Context, fromSupp, toTrader *
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	messageSize := sizeofRemoveOfferMessage(&util.OfferRemoveMsg{FromSupplier: *fromSupp, ToTrader: *toTrader, Offer: *offer})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(messageSize))
+	messageSize := sizeofRemoveOfferMessage(r.sizes, &util.OfferRemoveMsg{FromSupplier: *fromSupp, ToTrader: *toTrader, Offer: *offer})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.RemoveOfferMsg, 1, int64(messageSize))
 
 	toNode.RemoveOffer(ctx, fromSupp, toTrader, offer)
+	r.offerRemoved(fromSupp.IP, offer)
 
 	// Collect Metrics (fromNode)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(8))
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.RemoveOfferMsg, 1, int64(sizeofAckMessage(r.sizes)))
 
 	return nil
 }
@@ -97,15 +108,15 @@ func (r *RemoteClientMock) GetOffers(ctx context.Context, fromNode, toTrader *ty
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	toMessageSize := sizeofGetOffersMessage(&util.GetOffersMsg{FromNode: *fromNode, ToTrader: *toTrader, Relay: relay})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(toMessageSize))
+	toMessageSize := sizeofGetOffersMessage(r.sizes, &util.GetOffersMsg{FromNode: *fromNode, ToTrader: *toTrader, Relay: relay})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.GetOffersMsg, 1, int64(toMessageSize))
 	r.collector.IncrMessagesTradedRequest(types.RequestID(ctx), 1)
 
 	offers := toNode.GetOffers(ctx, fromNode, toTrader, relay)
 
 	// Collect Metrics (fromNode)
-	fromMessageSize := sizeofAvailableOffersMessage(offers)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(fromMessageSize))
+	fromMessageSize := sizeofAvailableOffersMessage(r.sizes, offers)
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.GetOffersMsg, 1, int64(fromMessageSize))
 
 	return offers, nil
 }
@@ -115,13 +126,13 @@ func (r *RemoteClientMock) AdvertiseOffersNeighbor(ctx context.Context, fromTrad
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	messageSize := sizeofNeighborOfferMessage(&util.NeighborOffersMsg{FromNeighbor: *fromTrader, ToNeighbor: *toNeighborTrader, NeighborOffering: *traderOffering})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(messageSize))
+	messageSize := sizeofNeighborOfferMessage(r.sizes, &util.NeighborOffersMsg{FromNeighbor: *fromTrader, ToNeighbor: *toNeighborTrader, NeighborOffering: *traderOffering})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.AdvertiseOffersNeighborMsg, 1, int64(messageSize))
 
 	toNode.AdvertiseOffersNeighbor(ctx, fromTrader, toNeighborTrader, traderOffering)
 
 	// Collect Metrics (fromNode)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(8))
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.AdvertiseOffersNeighborMsg, 1, int64(sizeofAckMessage(r.sizes)))
 
 	return nil
 }
@@ -132,15 +143,18 @@ func (r *RemoteClientMock) LaunchContainer(ctx context.Context, fromBuyer, toSup
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	toMessageSize := sizeofLaunchContainerMessage(&util.LaunchContainerMsg{FromBuyer: *fromBuyer, Offer: *offer, ContainersConfigs: containersConfigs})
-	r.collector.MessageReceived(toNodeIndex, 1, int64(toMessageSize))
+	toMessageSize := sizeofLaunchContainerMessage(r.sizes, &util.LaunchContainerMsg{FromBuyer: *fromBuyer, Offer: *offer, ContainersConfigs: containersConfigs})
+	r.collector.MessageReceived(fromNodeIndex, toNodeIndex, metrics.LaunchContainerMsg, 1, int64(toMessageSize))
 	r.collector.IncrMessagesTradedRequest(types.RequestID(ctx), 1)
 
 	containersStatus, requestErr := toNode.LaunchContainers(ctx, fromBuyer, offer, containersConfigs)
+	for _, containerStatus := range containersStatus {
+		r.collector.ContainerLaunched(types.RequestID(ctx), r.nodeService.ContainerPullTime(toNodeIndex, containerStatus.ContainerID))
+	}
 
 	// Collect Metrics (fromNode)
-	fromMessageSize := sizeofContainersStatusMessage(containersStatus)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(fromMessageSize))
+	fromMessageSize := sizeofContainersStatusMessage(r.sizes, containersStatus)
+	r.collector.MessageReceived(toNodeIndex, fromNodeIndex, metrics.LaunchContainerMsg, 1, int64(fromMessageSize))
 
 	return containersStatus, requestErr
 }
@@ -150,13 +164,13 @@ func (r *RemoteClientMock) StopLocalContainer(ctx context.Context, toSupplier *t
 	_, fromNodeIndex := r.nodeService.NodeByGUID(types.NodeGUID(ctx))
 
 	// Collect Metrics (toNode)
-	messageSize := sizeofStopLocalContainerMessage(&util.StopLocalContainerMsg{ContainerID: containerID})
-	r.collector.MessageReceived(nodeIndex, 1, int64(messageSize))
+	messageSize := sizeofStopLocalContainerMessage(r.sizes, &util.StopLocalContainerMsg{ContainerID: containerID})
+	r.collector.MessageReceived(fromNodeIndex, nodeIndex, metrics.StopLocalContainerMsg, 1, int64(messageSize))
 
 	requestErr := node.StopLocalContainer(ctx, containerID)
 
 	// Collect Metrics (fromNode)
-	r.collector.MessageReceived(fromNodeIndex, 1, int64(8))
+	r.collector.MessageReceived(nodeIndex, fromNodeIndex, metrics.StopLocalContainerMsg, 1, int64(sizeofAckMessage(r.sizes)))
 
 	return requestErr
 }
@@ -0,0 +1,250 @@
+package caravela
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api"
+	"github.com/strabox/caravela/api/rest/configuration"
+	"github.com/strabox/caravela/api/rest/containers"
+	"github.com/strabox/caravela/api/rest/discovery"
+	"github.com/strabox/caravela/api/rest/scheduling"
+	"github.com/strabox/caravela/api/rest/user"
+	"github.com/strabox/caravela/api/types"
+	systemConfiguration "github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// apiLogTag log's tag for the nodes' API servers.
+const apiLogTag = "API"
+
+// apiRequestsSource is the source of the requests, submitted through the API, in the metrics.
+const apiRequestsSource = "api"
+
+// errSimulationEnded is returned by the API calls that didn't run because the simulation ended.
+var errSimulationEnded = errors.New("the simulation ended")
+
+// apiServerKey is the key of the request's context that holds the API server that received the request.
+type apiServerKey struct{}
+
+var (
+	apiRouter     *mux.Router // Router with the Caravela's REST API endpoints, shared by all the API servers.
+	apiRouterOnce sync.Once
+)
+
+// APIServer serves the Caravela's REST API of a simulated node in a loopback port.
+// It implements the github.com/strabox/caravela/api Server interface.
+type APIServer struct {
+	nodeIndex  int                // Index of the node that serves the API.
+	port       int                // Port where the API is served.
+	node       api.LocalNode      // Node that handles the API requests.
+	httpServer *http.Server       // Web server of the API.
+	calls      chan<- func()      // Queue of the API calls, run by the engine in its ticks.
+	stopped    chan struct{}      // Closed when the server stops, the calls queued never run.
+	collector  *metrics.Collector // Collects the metrics of the requests submitted through the API.
+}
+
+// NewAPIServer creates a new API server for the node with the given index. The API calls are queued in the
+// calls channel, the engine runs them in its ticks so they don't race with the simulation.
+func NewAPIServer(nodeIndex, port int, calls chan<- func(), collector *metrics.Collector) *APIServer {
+	return &APIServer{
+		nodeIndex:  nodeIndex,
+		port:       port,
+		node:       nil,
+		httpServer: nil,
+		calls:      calls,
+		stopped:    make(chan struct{}),
+		collector:  collector,
+	}
+}
+
+// ServeHTTP attends the API requests, tagging them with the server so they reach its node.
+func (server *APIServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	apiRouter.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), apiServerKey{}, server)))
+}
+
+// ===============================================================================
+// =							  API Server Interface                           =
+// ===============================================================================
+
+func (server *APIServer) Start(thisNode api.LocalNode) error {
+	// The Caravela's REST handlers are bound to a single node, so they are bound to the dispatcher that
+	// forwards each request to the node of the server that received it.
+	apiRouterOnce.Do(func() {
+		apiRouter = mux.NewRouter()
+		configuration.Init(apiRouter, apiDispatcher{})
+		containers.Init(apiRouter, apiDispatcher{})
+		discovery.Init(apiRouter, apiDispatcher{})
+		scheduling.Init(apiRouter, apiDispatcher{})
+		user.Init(apiRouter, apiDispatcher{})
+	})
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", server.port))
+	if err != nil {
+		return err
+	}
+	server.node = thisNode
+	server.httpServer = &http.Server{Handler: server}
+	go server.httpServer.Serve(listener)
+
+	util.Log.Infof(util.LogTag(apiLogTag)+"Node %d serving the API in %s", server.nodeIndex, listener.Addr())
+	return nil
+}
+
+func (server *APIServer) Stop() {
+	if server.httpServer != nil {
+		close(server.stopped)
+		server.httpServer.Close()
+		server.httpServer = nil
+	}
+}
+
+// ===============================================================================
+// =							  API Dispatcher                                 =
+// ===============================================================================
+
+// apiDispatcher forwards the API requests to the node of the server that received them.
+// It implements the github.com/strabox/caravela/api LocalNode interface.
+type apiDispatcher struct{}
+
+// server returns the API server that received the request.
+func (apiDispatcher) server(ctx context.Context) *APIServer {
+	return ctx.Value(apiServerKey{}).(*APIServer)
+}
+
+// apiResult holds the results of an API call run by the engine.
+type apiResult struct {
+	value interface{} // Value returned by the call (if any).
+	err   error       // Error returned by the call (if any).
+}
+
+// run queues the API call to be run by the engine and waits for its results, the call doesn't run if the
+// simulation ends first. The results are sent back through a channel of the call, so a call abandoned when the
+// simulation ends doesn't write them while they are being returned.
+func (d apiDispatcher) run(ctx context.Context, call func() apiResult) apiResult {
+	server := d.server(ctx)
+	results := make(chan apiResult, 1) // Buffered, an abandoned call doesn't block the engine.
+	select {
+	case server.calls <- func() { results <- call() }:
+	case <-server.stopped:
+		return apiResult{err: errSimulationEnded}
+	}
+	select {
+	case result := <-results:
+		return result
+	case <-server.stopped:
+		return apiResult{err: errSimulationEnded}
+	}
+}
+
+func (d apiDispatcher) Configuration(ctx context.Context) *systemConfiguration.Configuration {
+	return d.server(ctx).node.Configuration(ctx)
+}
+
+func (d apiDispatcher) StopLocalContainer(ctx context.Context, containerID string) error {
+	return d.run(ctx, func() apiResult {
+		return apiResult{err: d.server(ctx).node.StopLocalContainer(ctx, containerID)}
+	}).err
+}
+
+func (d apiDispatcher) CreateOffer(ctx context.Context, fromNode, toNode *types.Node, offer *types.Offer) {
+	d.run(ctx, func() apiResult {
+		d.server(ctx).node.CreateOffer(ctx, fromNode, toNode, offer)
+		return apiResult{}
+	})
+}
+
+func (d apiDispatcher) RefreshOffer(ctx context.Context, fromTrader *types.Node, offer *types.Offer) bool {
+	result := d.run(ctx, func() apiResult {
+		return apiResult{value: d.server(ctx).node.RefreshOffer(ctx, fromTrader, offer)}
+	})
+	refreshed, _ := result.value.(bool)
+	return refreshed
+}
+
+func (d apiDispatcher) UpdateOffer(ctx context.Context, fromSupplier, toTrader *types.Node, offer *types.Offer) {
+	d.run(ctx, func() apiResult {
+		d.server(ctx).node.UpdateOffer(ctx, fromSupplier, toTrader, offer)
+		return apiResult{}
+	})
+}
+
+func (d apiDispatcher) RemoveOffer(ctx context.Context, fromSupp, toTrader *types.Node, offer *types.Offer) {
+	d.run(ctx, func() apiResult {
+		d.server(ctx).node.RemoveOffer(ctx, fromSupp, toTrader, offer)
+		return apiResult{}
+	})
+}
+
+func (d apiDispatcher) GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) []types.AvailableOffer {
+	result := d.run(ctx, func() apiResult {
+		return apiResult{value: d.server(ctx).node.GetOffers(ctx, fromNode, toTrader, relay)}
+	})
+	offers, _ := result.value.([]types.AvailableOffer)
+	return offers
+}
+
+func (d apiDispatcher) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighborTrader, traderOffering *types.Node) {
+	d.run(ctx, func() apiResult {
+		d.server(ctx).node.AdvertiseOffersNeighbor(ctx, fromTrader, toNeighborTrader, traderOffering)
+		return apiResult{}
+	})
+}
+
+func (d apiDispatcher) LaunchContainers(ctx context.Context, fromBuyer *types.Node, offer *types.Offer,
+	containerConfig []types.ContainerConfig) ([]types.ContainerStatus, error) {
+	result := d.run(ctx, func() apiResult {
+		contStatus, err := d.server(ctx).node.LaunchContainers(ctx, fromBuyer, offer, containerConfig)
+		return apiResult{value: contStatus, err: err}
+	})
+	contStatus, _ := result.value.([]types.ContainerStatus)
+	return contStatus, result.err
+}
+
+// SubmitContainers submits the user's containers in the node, collecting the request's metrics like the
+// requests of the feeders.
+func (d apiDispatcher) SubmitContainers(ctx context.Context, containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+	server := d.server(ctx)
+	resources := types.Resources{}
+	for _, containerConfig := range containersConfigs {
+		resources.CPUClass = containerConfig.Resources.CPUClass
+		resources.CPUs += containerConfig.Resources.CPUs
+		resources.Memory += containerConfig.Resources.Memory
+	}
+
+	result := d.run(ctx, func() apiResult {
+		requestID := guid.NewGUIDRandom().String() // Generate a GUID for tracking the request inside Caravela.
+		requestCtx := context.WithValue(ctx, types.RequestIDKey, requestID)
+		server.collector.CreateRunRequest(server.nodeIndex, requestID, apiRequestsSource, "", resources)
+		contStatus, err := server.node.SubmitContainers(requestCtx, containersConfigs)
+		server.collector.ArchiveRunRequest(requestID, err == nil)
+		return apiResult{value: contStatus, err: err}
+	})
+	contStatus, _ := result.value.([]types.ContainerStatus)
+	return contStatus, result.err
+}
+
+func (d apiDispatcher) ListContainers(ctx context.Context) []types.ContainerStatus {
+	result := d.run(ctx, func() apiResult {
+		return apiResult{value: d.server(ctx).node.ListContainers(ctx)}
+	})
+	contStatus, _ := result.value.([]types.ContainerStatus)
+	return contStatus
+}
+
+func (d apiDispatcher) StopContainers(ctx context.Context, containersIDs []string) error {
+	return d.run(ctx, func() apiResult {
+		return apiResult{err: d.server(ctx).node.StopContainers(ctx, containersIDs)}
+	}).err
+}
+
+// Stop ignores the requests to stop the node, the simulated nodes run until the simulation ends.
+func (d apiDispatcher) Stop(ctx context.Context) {
+	util.Log.Warnf(util.LogTag(apiLogTag)+"Node %d can't be stopped through the API", d.server(ctx).nodeIndex)
+}
@@ -1,62 +1,83 @@
 package caravela
 
 import (
-	"encoding/json"
+	"github.com/strabox/caravela-sim/mocks/wire"
 	"github.com/strabox/caravela/api/rest/util"
 	"github.com/strabox/caravela/api/types"
+	"net/http"
 )
 
-func sizeofCreateOfferMessage(msg *util.CreateOfferMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+// Types of the messages traded between the nodes, used by the message's size models (e.g. the names of the
+// message's types in the fixed size model's configuration).
+const (
+	createOfferMsgType          = "CreateOffer"
+	refreshOfferMsgType         = "RefreshOffer"
+	refreshOfferResponseMsgType = "RefreshOfferResponse"
+	updateOfferMsgType          = "UpdateOffer"
+	removeOfferMsgType          = "RemoveOffer"
+	getOffersMsgType            = "GetOffers"
+	launchContainerMsgType      = "LaunchContainer"
+	stopLocalContainerMsgType   = "StopLocalContainer"
+	neighborOffersMsgType       = "NeighborOffers"
+	availableOffersMsgType      = "AvailableOffers"
+	containersStatusMsgType     = "ContainersStatus"
+	ackMsgType                  = "Ack"
+)
+
+func sizeofCreateOfferMessage(sizes wire.SizeModel, msg *util.CreateOfferMsg) int {
+	return sizes.Size(createOfferMsgType, msg)
+}
+
+func sizeofRefreshOfferMessage(sizes wire.SizeModel, msg *util.RefreshOfferMsg) int {
+	return sizes.Size(refreshOfferMsgType, msg)
 }
 
-func sizeofRefreshOfferMessage(msg *util.RefreshOfferMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofRefreshOfferMessageResponse(sizes wire.SizeModel, msg *util.RefreshOfferResponseMsg) int {
+	return sizes.Size(refreshOfferResponseMsgType, msg)
 }
 
-func sizeofRefreshOfferMessageResponse(msg *util.RefreshOfferResponseMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofUpdateOfferMessage(sizes wire.SizeModel, msg *util.UpdateOfferMsg) int {
+	return sizes.Size(updateOfferMsgType, msg)
 }
 
-func sizeofUpdateOfferMessage(msg *util.UpdateOfferMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofRemoveOfferMessage(sizes wire.SizeModel, msg *util.OfferRemoveMsg) int {
+	return sizes.Size(removeOfferMsgType, msg)
 }
 
-func sizeofRemoveOfferMessage(msg *util.OfferRemoveMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofGetOffersMessage(sizes wire.SizeModel, msg *util.GetOffersMsg) int {
+	return sizes.Size(getOffersMsgType, msg)
 }
 
-func sizeofGetOffersMessage(msg *util.GetOffersMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofLaunchContainerMessage(sizes wire.SizeModel, msg *util.LaunchContainerMsg) int {
+	return sizes.Size(launchContainerMsgType, msg)
 }
 
-func sizeofLaunchContainerMessage(msg *util.LaunchContainerMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofStopLocalContainerMessage(sizes wire.SizeModel, msg *util.StopLocalContainerMsg) int {
+	return sizes.Size(stopLocalContainerMsgType, msg)
 }
 
-func sizeofStopLocalContainerMessage(msg *util.StopLocalContainerMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofNeighborOfferMessage(sizes wire.SizeModel, msg *util.NeighborOffersMsg) int {
+	return sizes.Size(neighborOffersMsgType, msg)
 }
 
-func sizeofNeighborOfferMessage(msg *util.NeighborOffersMsg) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofAvailableOffersMessage(sizes wire.SizeModel, msg []types.AvailableOffer) int {
+	return sizes.Size(availableOffersMsgType, msg)
 }
 
-func sizeofAvailableOffersMessage(msg []types.AvailableOffer) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+func sizeofContainersStatusMessage(sizes wire.SizeModel, msg []types.ContainerStatus) int {
+	return sizes.Size(containersStatusMsgType, msg)
+}
+
+// Size (bytes) of the acknowledgement of the requests without a response's payload in the REST encoding,
+// estimated by the simulator and kept by the json size model.
+const ackMessageSizeREST = 8
+
+// ackMsg is the payload of the acknowledgements: the HTTP status of the request.
+type ackMsg struct {
+	Status int `json:"Status"`
 }
 
-func sizeofContainersStatusMessage(msg []types.ContainerStatus) int {
-	string, _ := json.Marshal(msg)
-	return len(string)
+// sizeofAckMessage returns the size of the acknowledgement of the requests without a response's payload.
+func sizeofAckMessage(sizes wire.SizeModel) int {
+	return wire.EstimatedSize(sizes, ackMsgType, &ackMsg{Status: http.StatusOK}, ackMessageSizeREST)
 }
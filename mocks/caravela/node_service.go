@@ -1,9 +1,14 @@
 package caravela
 
-import "github.com/strabox/caravela/node"
+import (
+	"github.com/strabox/caravela/node"
+	"time"
+)
 
-// simNodeService provides an interface to obtain nodes from its IPs or GUIDs.
+// simNodeService provides an interface to obtain nodes from its IPs or GUIDs, and the state of the nodes'
+// containers.
 type simNodeService interface {
 	NodeByIP(ip string) (*node.Node, int)
 	NodeByGUID(guid string) (*node.Node, int)
+	ContainerPullTime(nodeIndex int, containerID string) time.Duration
 }
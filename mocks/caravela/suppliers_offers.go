@@ -0,0 +1,55 @@
+package caravela
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"sync"
+)
+
+// supplierOffers holds the offers that a supplier has in the traders.
+type supplierOffers struct {
+	mutex  sync.Mutex
+	offers map[int64]types.Resources // Map of OfferID<->Resources offered when the offer was created.
+}
+
+// SupplierOffer returns the ID and the resources of the most recent offer that the supplier, with the given IP,
+// has in the traders. It returns false if the supplier has no offers, e.g. the backends that don't use offers.
+// The engine uses it to launch the capacity's reservations of the offering backends' nodes.
+func (r *RemoteClientMock) SupplierOffer(supplierIP string) (int64, types.Resources, bool) {
+	value, exist := r.suppliersOffers.Load(supplierIP)
+	if !exist {
+		return 0, types.Resources{}, false
+	}
+	supplier := value.(*supplierOffers)
+	supplier.mutex.Lock()
+	defer supplier.mutex.Unlock()
+
+	offerID, found := int64(0), false
+	for id := range supplier.offers {
+		if !found || id > offerID {
+			offerID, found = id, true
+		}
+	}
+	return offerID, supplier.offers[offerID], found
+}
+
+// offerCreated registers an offer created by the supplier.
+func (r *RemoteClientMock) offerCreated(supplierIP string, offer *types.Offer) {
+	value, exist := r.suppliersOffers.Load(supplierIP)
+	if !exist {
+		value, _ = r.suppliersOffers.LoadOrStore(supplierIP, &supplierOffers{offers: make(map[int64]types.Resources)})
+	}
+	supplier := value.(*supplierOffers)
+	supplier.mutex.Lock()
+	defer supplier.mutex.Unlock()
+	supplier.offers[offer.ID] = offer.FreeResources
+}
+
+// offerRemoved registers an offer removed by the supplier.
+func (r *RemoteClientMock) offerRemoved(supplierIP string, offer *types.Offer) {
+	if value, exist := r.suppliersOffers.Load(supplierIP); exist {
+		supplier := value.(*supplierOffers)
+		supplier.mutex.Lock()
+		defer supplier.mutex.Unlock()
+		delete(supplier.offers, offer.ID)
+	}
+}
@@ -2,35 +2,94 @@ package docker
 
 import (
 	"github.com/pkg/errors"
+	"github.com/strabox/caravela-sim/engine/metrics"
 	"github.com/strabox/caravela-sim/util"
 	"github.com/strabox/caravela/api/types"
 	myContainer "github.com/strabox/caravela/docker/container"
 	"github.com/strabox/caravela/docker/events"
+	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // containerIDSize is the size of the container's IDs.
 const containerIDSize = 64
 
+// ReservationContainerName is the name of the containers that hold the resources reclaimed by the node's owner,
+// so the node stops offering them. They don't use the engine's capacity, images or end on their own.
+const ReservationContainerName = "capacity-reservation"
+
 // ClientMock mocks the interactions with the docker daemon.
 // It implements the github.com/strabox/caravela/node/external DockerClient interface.
 type ClientMock struct {
+	nodeIndex          int // Index of the node that uses the docker engine.
 	maxCPUS            int
 	maxMemory          int
 	numOfContainers    int64
-	containersRunning  sync.Map
+	containersRunning  sync.Map // Map of ContainerID<->types.Resources used by the container.
 	resourcesGenerator ResourcesGenerator
+
+	capacityMutex   sync.Mutex                 // Protects the capacity and the used resources.
+	capacityLimited bool                       // True if the engine's capacity was changed during the simulation.
+	capacity        types.Resources            // Current capacity of the engine (in Caravela's units, i.e. CPU slices).
+	usedResources   types.Resources            // Resources used by the containers running (in Caravela's units).
+	reservations    map[string]types.Resources // Map of ContainerID<->Resources held by the reservation containers.
+
+	containerEvents *ContainerEvents        // Draws when the containers end on their own (nil if they never do).
+	randomGenerator *rand.Rand              // Pseudo-random generator of the containers' endings.
+	containersEnd   map[string]containerEnd // Map of ContainerID<->When and why the container ends on its own.
+	currentTick     int                     // Current tick of the simulation.
+	eventsChan      chan *events.Event      // Channel of the docker engine's events (nil until it is started).
+	pendingEvents   []*events.Event         // Events that didn't fit in the events channel yet.
+
+	images          *ImageRegistry           // Registry of the images pulled (nil if the images aren't simulated).
+	imageCache      *imageCache              // Images stored in the docker engine.
+	containersImage map[string]string        // Map of ContainerID<->Image, of the containers whose image is cached.
+	containersPull  map[string]time.Duration // Map of ContainerID<->Time pulling its image, of the containers pulled.
+	collector       *metrics.Collector       // Metrics collector.
 }
 
-// NewClientMock creates a new docker client mock to be used.
-func NewClientMock(resourcesGenerator ResourcesGenerator) *ClientMock {
+// NewClientMock creates a new docker client mock to be used by the node with the given index.
+// The containerEvents can be nil if the containers only end when they are stopped and the images can be nil
+// if the containers' images aren't simulated.
+func NewClientMock(nodeIndex int, resourcesGenerator ResourcesGenerator, containerEvents *ContainerEvents,
+	images *ImageRegistry, collector *metrics.Collector) *ClientMock {
+	var randomGenerator *rand.Rand
+	if containerEvents != nil {
+		randomGenerator = containerEvents.randomGenerator(nodeIndex)
+	}
+	var cache *imageCache
+	if images != nil {
+		cache = newImageCache(images.cacheSize)
+	}
 	return &ClientMock{
+		nodeIndex:          nodeIndex,
 		maxCPUS:            0,
 		maxMemory:          0,
 		numOfContainers:    0,
 		containersRunning:  sync.Map{},
 		resourcesGenerator: resourcesGenerator,
+
+		capacityMutex:   sync.Mutex{},
+		capacityLimited: false,
+		capacity:        types.Resources{},
+		usedResources:   types.Resources{},
+		reservations:    make(map[string]types.Resources),
+
+		containerEvents: containerEvents,
+		randomGenerator: randomGenerator,
+		containersEnd:   make(map[string]containerEnd),
+		currentTick:     0,
+		eventsChan:      nil,
+		pendingEvents:   make([]*events.Event, 0),
+
+		images:          images,
+		imageCache:      cache,
+		containersImage: make(map[string]string),
+		containersPull:  make(map[string]time.Duration),
+		collector:       collector,
 	}
 }
 
@@ -42,19 +101,166 @@ func (cliMock *ClientMock) MaxResourcesAvailable() (int, int) {
 	return cliMock.maxCPUS, cliMock.maxMemory
 }
 
+// SetCapacity changes the capacity of the docker engine (e.g. the node's owner reclaimed part of it).
+// It returns the IDs of the containers that must be evicted, the ones using more resources first, so the
+// resources used fit in the new capacity.
+func (cliMock *ClientMock) SetCapacity(capacity types.Resources) []string {
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	cliMock.capacity = capacity
+	cliMock.capacityLimited = true
+
+	used := cliMock.usedResources
+	if used.CPUs <= capacity.CPUs && used.Memory <= capacity.Memory {
+		return nil
+	}
+
+	containerIDs := make([]string, 0)
+	containersResources := make(map[string]types.Resources)
+	cliMock.containersRunning.Range(func(key, value interface{}) bool {
+		containerIDs = append(containerIDs, key.(string))
+		containersResources[key.(string)] = value.(types.Resources)
+		return true
+	})
+	sort.Slice(containerIDs, func(i, j int) bool {
+		iRes, jRes := containersResources[containerIDs[i]], containersResources[containerIDs[j]]
+		if iRes.Memory == jRes.Memory {
+			return iRes.CPUs > jRes.CPUs
+		}
+		return iRes.Memory > jRes.Memory
+	})
+
+	evicted := make([]string, 0)
+	for _, containerID := range containerIDs {
+		if used.CPUs <= capacity.CPUs && used.Memory <= capacity.Memory {
+			break
+		}
+		used.CPUs -= containersResources[containerID].CPUs
+		used.Memory -= containersResources[containerID].Memory
+		evicted = append(evicted, containerID)
+	}
+	return evicted
+}
+
+// Reservations returns the IDs of the reservation containers and the resources that they hold.
+func (cliMock *ClientMock) Reservations() ([]string, types.Resources) {
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	containerIDs, reserved := make([]string, 0, len(cliMock.reservations)), types.Resources{}
+	for containerID, resources := range cliMock.reservations {
+		containerIDs = append(containerIDs, containerID)
+		reserved.CPUs += resources.CPUs
+		reserved.Memory += resources.Memory
+	}
+	return containerIDs, reserved
+}
+
+// ContainerPullTime returns the time spent pulling the image of the running container (0 if it was cached).
+func (cliMock *ClientMock) ContainerPullTime(containerID string) time.Duration {
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	return cliMock.containersPull[containerID]
+}
+
+// Tick advances the docker engine to the given tick, ending the containers whose time is up. A died event
+// of each container ended is sent through the events channel returned by Start.
+// It returns the number of containers ended by each cause.
+func (cliMock *ClientMock) Tick(tick int) [NumEndCauses]int64 {
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	cliMock.currentTick = tick + 1 // The containers run from now on start in the next tick.
+
+	var ended [NumEndCauses]int64
+	for containerID, end := range cliMock.containersEnd {
+		if end.tick > tick {
+			continue
+		}
+		cliMock.removeContainer(containerID)
+		cliMock.pendingEvents = append(cliMock.pendingEvents, &events.Event{Type: events.ContainerDied, Value: containerID})
+		ended[end.cause]++
+	}
+
+	if cliMock.eventsChan == nil { // Nobody is listening to the events.
+		cliMock.pendingEvents = cliMock.pendingEvents[:0]
+		return ended
+	}
+	sent := 0
+sendLoop:
+	for _, event := range cliMock.pendingEvents {
+		select {
+		case cliMock.eventsChan <- event:
+			sent++
+		default: // The channel is full, the remaining events are sent in the next ticks.
+			break sendLoop
+		}
+	}
+	cliMock.pendingEvents = append(cliMock.pendingEvents[:0], cliMock.pendingEvents[sent:]...)
+	return ended
+}
+
+// pullImage gets the container's image from the image cache, pulling it from the registry on a cache miss.
+// It returns the time spent pulling the image and it must be called with the capacity's mutex held.
+func (cliMock *ClientMock) pullImage(containerID, image string) time.Duration {
+	size, exist := cliMock.images.imageSize(image)
+	if !exist {
+		return 0
+	}
+
+	cacheHit, cached := cliMock.imageCache.acquire(image, size)
+	if cached {
+		cliMock.containersImage[containerID] = image
+	}
+	if cacheHit {
+		cliMock.collector.ImageRequested(cliMock.nodeIndex, true, 0, 0)
+		return 0
+	}
+	pullTime := cliMock.images.pullTime(size)
+	cliMock.containersPull[containerID] = pullTime
+	cliMock.collector.ImageRequested(cliMock.nodeIndex, false, int64(size)*1024*1024, pullTime)
+	return pullTime
+}
+
+// removeContainer removes the container from the docker engine, releasing its resources.
+// It must be called with the capacity's mutex held.
+func (cliMock *ClientMock) removeContainer(containerID string) bool {
+	if _, exist := cliMock.reservations[containerID]; exist {
+		delete(cliMock.reservations, containerID)
+		return true
+	}
+	resources, exist := cliMock.containersRunning.Load(containerID)
+	if !exist {
+		return false
+	}
+	cliMock.containersRunning.Delete(containerID)
+	delete(cliMock.containersEnd, containerID)
+	delete(cliMock.containersPull, containerID)
+	if image, exist := cliMock.containersImage[containerID]; exist {
+		cliMock.imageCache.release(image)
+		delete(cliMock.containersImage, containerID)
+	}
+	atomic.AddInt64(&cliMock.numOfContainers, -1)
+	cliMock.usedResources.CPUs -= resources.(types.Resources).CPUs
+	cliMock.usedResources.Memory -= resources.(types.Resources).Memory
+	return true
+}
+
 // ===============================================================================
 // =						   DockerClient Interface                            =
 // ===============================================================================
 
 func (cliMock *ClientMock) Start() <-chan *events.Event {
-	// Do Nothing (Not necessary for the engine)
-	return nil
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	if cliMock.eventsChan == nil {
+		cliMock.eventsChan = make(chan *events.Event, eventsChanSize)
+	}
+	return cliMock.eventsChan
 }
 
 func (cliMock *ClientMock) GetDockerEngineTotalResources() (int, int, int) {
-	cpuClass, cpuCores, memory := cliMock.resourcesGenerator.Generate()
-	cliMock.maxCPUS += cpuCores
-	cliMock.maxMemory += memory
+	cpuClass, cpuCores, memory := cliMock.resourcesGenerator.Generate(cliMock.nodeIndex)
+	cliMock.maxCPUS = cpuCores
+	cliMock.maxMemory = memory
 	return cpuClass, cpuCores, memory
 }
 
@@ -68,10 +274,31 @@ func (cliMock *ClientMock) CheckContainerStatus(containerID string) (myContainer
 }
 
 func (cliMock *ClientMock) RunContainer(contConfig types.ContainerConfig) (*types.ContainerStatus, error) {
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	if contConfig.Name == ReservationContainerName {
+		reservationID := util.RandomString(containerIDSize)
+		cliMock.reservations[reservationID] = contConfig.Resources
+		return &types.ContainerStatus{ContainerConfig: contConfig, ContainerID: reservationID, Status: "Running"}, nil
+	}
+	if cliMock.capacityLimited && (cliMock.usedResources.CPUs+contConfig.Resources.CPUs > cliMock.capacity.CPUs ||
+		cliMock.usedResources.Memory+contConfig.Resources.Memory > cliMock.capacity.Memory) {
+		return nil, errors.New("not enough capacity in the docker engine")
+	}
+
 	// Generate a random ID for the container and store it in an HashMap
 	randomContainerID := util.RandomString(containerIDSize)
-	cliMock.containersRunning.Store(randomContainerID, true)
+	cliMock.containersRunning.Store(randomContainerID, contConfig.Resources)
 	atomic.AddInt64(&cliMock.numOfContainers, 1)
+	cliMock.usedResources.CPUs += contConfig.Resources.CPUs
+	cliMock.usedResources.Memory += contConfig.Resources.Memory
+	startTick := cliMock.currentTick
+	if cliMock.images != nil { // The container only starts running when its image is pulled.
+		startTick += cliMock.images.pullTicks(cliMock.pullImage(randomContainerID, contConfig.ImageKey))
+	}
+	if cliMock.containerEvents != nil {
+		cliMock.containersEnd[randomContainerID] = cliMock.containerEvents.draw(startTick, cliMock.randomGenerator)
+	}
 
 	return &types.ContainerStatus{
 		ContainerConfig: contConfig,
@@ -81,9 +308,9 @@ func (cliMock *ClientMock) RunContainer(contConfig types.ContainerConfig) (*type
 }
 
 func (cliMock *ClientMock) RemoveContainer(containerID string) error {
-	if _, exist := cliMock.containersRunning.Load(containerID); exist {
-		cliMock.containersRunning.Delete(containerID)
-		atomic.AddInt64(&cliMock.numOfContainers, -1)
+	cliMock.capacityMutex.Lock()
+	defer cliMock.capacityMutex.Unlock()
+	if cliMock.removeContainer(containerID) {
 		return nil
 	}
 	return errors.New("container does not exist in the docker engine")
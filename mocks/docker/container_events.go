@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"math"
+	"math/rand"
+)
+
+// eventsChanSize is the size of the buffer of the docker engine's events channel.
+const eventsChanSize = 64
+
+// EndCause is the cause of a container ending on its own.
+type EndCause int
+
+const (
+	Finished  EndCause = iota // The container finished its work.
+	Crashed                   // The container crashed.
+	OOMKilled                 // The container was killed for running out of memory.
+	NumEndCauses
+)
+
+var endCausesNames = [NumEndCauses]string{"Finished", "Crashed", "OOMKilled"}
+
+func (c EndCause) String() string {
+	return endCausesNames[c]
+}
+
+// containerEnd holds when (tick) and why a container ends on its own.
+type containerEnd struct {
+	tick  int
+	cause EndCause
+}
+
+// ContainerEvents draws when and why the containers end on their own from the configured distributions.
+// The containers end at the earliest of the times drawn.
+type ContainerEvents struct {
+	rngSeed       int64                                     // Seed used to derive the pseudo-random generator of each node.
+	distributions [NumEndCauses]*configuration.Distribution // Distribution of the time (in ticks) until each cause.
+}
+
+// NewContainerEvents creates the containers' events, it returns nil if the containers never end on their own.
+func NewContainerEvents(simConfigs *configuration.Configuration, rngSeed int64) *ContainerEvents {
+	if !simConfigs.HasContainerEvents() {
+		return nil
+	}
+	return &ContainerEvents{
+		rngSeed: rngSeed,
+		distributions: [NumEndCauses]*configuration.Distribution{
+			Finished:  simConfigs.ContainerLifetime(),
+			Crashed:   simConfigs.ContainerTimeToCrash(),
+			OOMKilled: simConfigs.ContainerTimeToOOM(),
+		},
+	}
+}
+
+// randomGenerator returns the pseudo-random generator of the node's containers, each node has its own so the
+// containers' endings don't depend on the order the nodes run them.
+func (e *ContainerEvents) randomGenerator(nodeIndex int) *rand.Rand {
+	return rand.New(rand.NewSource(e.rngSeed + int64(nodeIndex)))
+}
+
+// draw returns when and why a container started in the given tick ends.
+func (e *ContainerEvents) draw(startTick int, randomGenerator *rand.Rand) containerEnd {
+	end := containerEnd{tick: math.MaxInt32, cause: Finished}
+	for cause, distribution := range e.distributions {
+		if !distribution.IsSet() {
+			continue
+		}
+		// A container runs at least until the next tick.
+		ticks := math.Max(1, math.Round(distribution.Quantile(randomGenerator.Float64())))
+		ticks = math.Min(ticks, math.MaxInt32)
+		if startTick+int(ticks) < end.tick {
+			end = containerEnd{tick: startTick + int(ticks), cause: EndCause(cause)}
+		}
+	}
+	return end
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	"math"
+	"math/rand"
+)
+
+// distributionResourceGen is a resource generator that draws the maximum resources of each node from the
+// configured distributions, independently of Caravela's resources partitions.
+type distributionResourceGen struct {
+	rngSeed    int64                        // Seed used to derive the pseudo-random generator of each node.
+	simConfigs *configuration.Configuration // Simulator's configurations.
+}
+
+// newDistributionResourceGen creates a new distribution resource generator.
+func newDistributionResourceGen(simConfigs *configuration.Configuration, _ *caravelaConfigs.Configuration, rngSeed int64) (ResourcesGenerator, error) {
+	return &distributionResourceGen{
+		rngSeed:    rngSeed,
+		simConfigs: simConfigs,
+	}, nil
+}
+
+func (d *distributionResourceGen) Generate(nodeIndex int) (int, int, int) {
+	// Each node has its own generator so its resources don't depend on the order the nodes are created.
+	randomGenerator := rand.New(rand.NewSource(d.rngSeed + int64(nodeIndex)))
+	cpusProbability, memoryProbability := configuration.CorrelatedProbabilities(randomGenerator, d.simConfigs.DistributionGeneratorCorrelation())
+
+	cpus := math.Max(1, math.Round(d.simConfigs.DistributionGeneratorCPUs().Quantile(cpusProbability)))
+	memory := math.Max(1, math.Round(d.simConfigs.DistributionGeneratorMemory().Quantile(memoryProbability)))
+	return d.simConfigs.DistributionGeneratorCPUClass(), int(cpus), int(memory)
+}
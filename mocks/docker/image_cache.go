@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"container/list"
+	"github.com/strabox/caravela-sim/configuration"
+	"math"
+	"time"
+)
+
+// ImageRegistry holds the catalogue of images that the docker engines pull on their image cache misses.
+type ImageRegistry struct {
+	imagesSize    map[string]int // Map of ImageName<->Size of the image (in MB).
+	cacheSize     int            // Capacity (MB) of each docker engine's image cache.
+	pullBandwidth float64        // Bandwidth (MB/s) of a docker engine pulling an image.
+	pullLatency   time.Duration  // Latency of each pull.
+	ticksInterval time.Duration  // Simulated time of each tick.
+}
+
+// NewImageRegistry creates the images' registry, it returns nil if the images aren't simulated.
+func NewImageRegistry(simConfigs *configuration.Configuration) *ImageRegistry {
+	if !simConfigs.HasImages() {
+		return nil
+	}
+
+	imagesSize := make(map[string]int)
+	for _, image := range simConfigs.ImagesCatalogue() {
+		imagesSize[image.Name] = image.Size
+	}
+	return &ImageRegistry{
+		imagesSize:    imagesSize,
+		cacheSize:     simConfigs.ImageCacheSize(),
+		pullBandwidth: simConfigs.ImagePullBandwidth(),
+		pullLatency:   simConfigs.ImagePullLatency(),
+		ticksInterval: simConfigs.TicksInterval(),
+	}
+}
+
+// imageSize returns the size (in MB) of the image, the images out of the catalogue aren't simulated.
+func (r *ImageRegistry) imageSize(image string) (int, bool) {
+	size, exist := r.imagesSize[image]
+	return size, exist
+}
+
+// pullTime returns the time needed to pull an image with the given size (in MB).
+func (r *ImageRegistry) pullTime(size int) time.Duration {
+	return r.pullLatency + time.Duration(float64(size)/r.pullBandwidth*float64(time.Second))
+}
+
+// pullTicks returns the number of ticks that a pull, taking the given time, delays the container's start.
+func (r *ImageRegistry) pullTicks(pullTime time.Duration) int {
+	if pullTime <= 0 || r.ticksInterval <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(pullTime) / float64(r.ticksInterval)))
+}
+
+// cachedImage is an image stored in a docker engine's image cache.
+type cachedImage struct {
+	size       int           // Size of the image (in MB).
+	containers int           // Containers running that use the image.
+	element    *list.Element // Position of the image in the cache's recency list.
+}
+
+// imageCache is the image cache of a docker engine. When it is full the least recently used images, that aren't
+// used by running containers, are removed to store the new ones.
+type imageCache struct {
+	capacity int                     // Capacity of the cache (in MB).
+	used     int                     // Size of the images stored (in MB).
+	images   map[string]*cachedImage // Map of ImageName<->Image stored.
+	recency  *list.List              // Names of the images stored from the most to the least recently used.
+}
+
+// newImageCache creates an empty image cache with the given capacity (in MB).
+func newImageCache(capacity int) *imageCache {
+	return &imageCache{
+		capacity: capacity,
+		used:     0,
+		images:   make(map[string]*cachedImage),
+		recency:  list.New(),
+	}
+}
+
+// acquire registers a new container using the image, storing the image in the cache if it wasn't there.
+// It returns true if the image was already in the cache (otherwise it must be pulled) and true if the image is
+// stored in the cache, an image that doesn't fit even after removing the unused images isn't stored.
+func (c *imageCache) acquire(name string, size int) (bool, bool) {
+	if image, exist := c.images[name]; exist {
+		image.containers++
+		c.recency.MoveToFront(image.element)
+		return true, true
+	}
+	if size > c.capacity {
+		return false, false
+	}
+
+	for element := c.recency.Back(); element != nil && c.used+size > c.capacity; {
+		previous := element.Prev()
+		if victim := c.images[element.Value.(string)]; victim.containers == 0 {
+			c.recency.Remove(element)
+			delete(c.images, element.Value.(string))
+			c.used -= victim.size
+		}
+		element = previous
+	}
+	if c.used+size > c.capacity {
+		return false, false
+	}
+	c.images[name] = &cachedImage{size: size, containers: 1, element: c.recency.PushFront(name)}
+	c.used += size
+	return false, true
+}
+
+// release registers that a container using the image is no longer running.
+func (c *imageCache) release(name string) {
+	if image, exist := c.images[name]; exist && image.containers > 0 {
+		image.containers--
+	}
+}
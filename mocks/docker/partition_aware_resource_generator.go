@@ -22,7 +22,7 @@ func newPartitionAwareResourceGen(_ *configuration.Configuration, caravelaConfig
 	}, nil
 }
 
-func (p *partitionAwareResourceGen) Generate() (int, int, int) {
+func (p *partitionAwareResourceGen) Generate(_ int) (int, int, int) {
 	resourcesPartitions := p.caravelaConfigs.ResourcesPartitions()
 
 	cpAcc := 0
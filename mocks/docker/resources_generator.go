@@ -1,5 +1,7 @@
 package docker
 
+// ResourcesGenerator generates the maximum resources of the nodes' docker engines.
 type ResourcesGenerator interface {
-	Generate() (int, int, int)
+	// Generate returns the CPU class, the CPU cores and the memory of the node with the given index.
+	Generate(nodeIndex int) (int, int, int)
 }
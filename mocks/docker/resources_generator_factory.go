@@ -20,6 +20,8 @@ var generators = make(map[string]ResourceGenFactory)
 func init() {
 	RegisterResourceGen("static", newStaticResourceGen)
 	RegisterResourceGen("partition-fit", newPartitionAwareResourceGen)
+	RegisterResourceGen("trace", newTraceResourceGen)
+	RegisterResourceGen("distribution", newDistributionResourceGen)
 }
 
 // RegisterResourceGen can be used to register a new resource generator in order to be available.
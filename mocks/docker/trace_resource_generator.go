@@ -0,0 +1,126 @@
+package docker
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/util"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	caravelaUtil "github.com/strabox/caravela/util"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const logTraceGenTag = "TRACE-GEN"
+
+// nodeCapacity represents the capacity of a node read from the trace.
+type nodeCapacity struct {
+	cpuClass int
+	cpus     int
+	memory   int
+}
+
+// traceResourceGen is a resource generator that reads the maximum resources of the nodes from a CSV trace
+// (e.g. Google's machine_events table or an inventory export).
+type traceResourceGen struct {
+	capacities  []nodeCapacity // Capacities read from the trace.
+	assignments []int          // Index of the capacity assigned to each node (nil for sequential assignment).
+}
+
+// newTraceResourceGen creates a new trace resource generator.
+func newTraceResourceGen(simConfigs *configuration.Configuration, _ *caravelaConfigs.Configuration, rngSeed int64) (ResourcesGenerator, error) {
+	capacities, err := readNodesTrace(simConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []int
+	if simConfigs.TraceGeneratorAssignment() == "sample" {
+		randomGenerator := rand.New(caravelaUtil.NewSourceSafe(rand.NewSource(rngSeed)))
+		assignments = make([]int, simConfigs.TotalNumberOfNodes())
+		for i := range assignments {
+			assignments[i] = randomGenerator.Intn(len(capacities))
+		}
+	}
+
+	util.Log.Infof(util.LogTag(logTraceGenTag)+"Nodes' capacities read from the trace: %d", len(capacities))
+	return &traceResourceGen{
+		capacities:  capacities,
+		assignments: assignments,
+	}, nil
+}
+
+// readNodesTrace reads the nodes' capacities from the configured CSV file, skipping the rows without
+// valid capacities.
+func readNodesTrace(simConfigs *configuration.Configuration) ([]nodeCapacity, error) {
+	file, err := os.Open(simConfigs.TraceGeneratorFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("can't open the nodes trace: %s", err)
+	}
+	defer file.Close()
+
+	cpusColumn, memoryColumn, platformColumn, filterColumn := simConfigs.TraceGeneratorColumns()
+	cpusScale, memoryScale := simConfigs.TraceGeneratorScales()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	capacities := make([]nodeCapacity, 0)
+	skippedRows := 0
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("can't read the nodes trace: %s", err)
+		}
+		if row == 0 && simConfigs.TraceGeneratorHasHeader() {
+			continue
+		}
+
+		if filterColumn >= 0 && (filterColumn >= len(record) || strings.TrimSpace(record[filterColumn]) != simConfigs.TraceGeneratorFilterValue()) {
+			continue
+		}
+		if cpusColumn >= len(record) || memoryColumn >= len(record) {
+			skippedRows++
+			continue
+		}
+		cpus, errCPUs := strconv.ParseFloat(strings.TrimSpace(record[cpusColumn]), 64)
+		memory, errMemory := strconv.ParseFloat(strings.TrimSpace(record[memoryColumn]), 64)
+		if errCPUs != nil || errMemory != nil || cpus <= 0 || memory <= 0 { // e.g. Google's trace has empty capacities.
+			skippedRows++
+			continue
+		}
+
+		platform := ""
+		if platformColumn >= 0 && platformColumn < len(record) {
+			platform = strings.TrimSpace(record[platformColumn])
+		}
+
+		capacities = append(capacities, nodeCapacity{
+			cpuClass: simConfigs.TraceGeneratorCPUClass(platform),
+			cpus:     int(math.Max(1, math.Round(cpus*cpusScale))),
+			memory:   int(math.Max(1, math.Round(memory*memoryScale))),
+		})
+	}
+
+	if skippedRows > 0 {
+		util.Log.Warnf(util.LogTag(logTraceGenTag)+"Rows skipped without valid capacities: %d", skippedRows)
+	}
+	if len(capacities) == 0 {
+		return nil, fmt.Errorf("the nodes trace doesn't have valid capacities")
+	}
+	return capacities, nil
+}
+
+func (t *traceResourceGen) Generate(nodeIndex int) (int, int, int) {
+	capacityIndex := nodeIndex % len(t.capacities)
+	if t.assignments != nil {
+		capacityIndex = t.assignments[nodeIndex%len(t.assignments)]
+	}
+	capacity := t.capacities[capacityIndex]
+	return capacity.cpuClass, capacity.cpus, capacity.memory
+}
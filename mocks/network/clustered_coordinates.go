@@ -0,0 +1,30 @@
+package network
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"math/rand"
+)
+
+// newClusteredCoordinates places the nodes around cluster's centers (e.g. edge sites or regions) drawn
+// uniformly at random in the coordinates' space. Each node belongs to a random cluster and its distance to
+// the cluster's center follows a normal distribution in each dimension.
+func newClusteredCoordinates(simConfigs *configuration.Configuration, rngSeed int64) (*Coordinates, error) {
+	randomGenerator := rand.New(rand.NewSource(rngSeed))
+	dimensions := simConfigs.NetworkDimensions()
+	numClusters, clusterRadius := simConfigs.NetworkClusters()
+
+	centers := newCoordinates(numClusters, dimensions)
+	for i := range centers.points {
+		centers.points[i] = randomGenerator.Float64() * simConfigs.NetworkSpaceSize()
+	}
+
+	res := newCoordinates(simConfigs.TotalNumberOfNodes(), dimensions)
+	for node := 0; node < res.numNodes(); node++ {
+		center := centers.Point(randomGenerator.Intn(numClusters))
+		point := res.Point(node)
+		for d := range point {
+			point[d] = center[d] + randomGenerator.NormFloat64()*clusterRadius
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package network
+
+import "math"
+
+// Coordinates holds the network coordinates of the nodes. The euclidean distance between the coordinates of
+// two nodes is the latency (in milliseconds) between them.
+// Read-only after its creation, goroutine-safe.
+type Coordinates struct {
+	dimensions int       // Dimensions of the coordinates' space (0 if the nodes don't have coordinates).
+	points     []float64 // Coordinates of each node (dimensions values per node).
+}
+
+func newCoordinates(numNodes, dimensions int) *Coordinates {
+	return &Coordinates{
+		dimensions: dimensions,
+		points:     make([]float64, numNodes*dimensions),
+	}
+}
+
+// Enabled returns true if the nodes have coordinates, otherwise the latency between them is always 0.
+func (c *Coordinates) Enabled() bool {
+	return c.dimensions > 0
+}
+
+// Latency returns the latency (ms) between the two nodes, given the nodes' indexes.
+func (c *Coordinates) Latency(nodeA, nodeB int) float64 {
+	pointA, pointB := c.points[nodeA*c.dimensions:], c.points[nodeB*c.dimensions:]
+	res := 0.0
+	for d := 0; d < c.dimensions; d++ {
+		diff := pointA[d] - pointB[d]
+		res += diff * diff
+	}
+	return math.Sqrt(res)
+}
+
+// Point returns the node's coordinates, given the node's index.
+func (c *Coordinates) Point(nodeIndex int) []float64 {
+	return c.points[nodeIndex*c.dimensions : (nodeIndex+1)*c.dimensions]
+}
+
+func (c *Coordinates) numNodes() int {
+	if c.dimensions == 0 {
+		return 0
+	}
+	return len(c.points) / c.dimensions
+}
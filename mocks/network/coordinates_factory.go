@@ -0,0 +1,71 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/util"
+	"log"
+	"strings"
+)
+
+// networkLogTag network's coordinates log tag.
+const networkLogTag = "SIM-NET"
+
+// CoordinatesGenFactory represents a method that places the nodes in the network coordinates' space.
+type CoordinatesGenFactory func(simConfigs *configuration.Configuration, rngSeed int64) (*Coordinates, error)
+
+// generators holds all the registered coordinates generators available.
+var generators = make(map[string]CoordinatesGenFactory)
+
+// init initializes our predefined coordinates generators.
+func init() {
+	RegisterCoordinatesGen("none", newNoCoordinates)
+	RegisterCoordinatesGen("random", newRandomCoordinates)
+	RegisterCoordinatesGen("clustered", newClusteredCoordinates)
+	RegisterCoordinatesGen("file", newFileCoordinates)
+}
+
+// RegisterCoordinatesGen can be used to register a new coordinates generator in order to be available.
+func RegisterCoordinatesGen(coordinatesGenName string, factory CoordinatesGenFactory) {
+	if factory == nil {
+		log.Panic("nil coordinates generator registering")
+	}
+	_, exist := generators[coordinatesGenName]
+	if exist {
+		util.Log.Warnf("coordinates generator %s is being overridden", coordinatesGenName)
+	}
+	generators[coordinatesGenName] = factory
+}
+
+// CreateCoordinates is used to obtain the nodes' network coordinates based on the configurations.
+func CreateCoordinates(simConfigs *configuration.Configuration, rngSeed int64) *Coordinates {
+	configuredCoordinatesGen := simConfigs.NetworkCoordinates()
+
+	coordinatesGenFactory, exist := generators[configuredCoordinatesGen]
+	if !exist {
+		existingGenerators := make([]string, 0, len(generators))
+		for genName := range generators {
+			existingGenerators = append(existingGenerators, genName)
+		}
+		err := errors.New(fmt.Sprintf("Invalid %s coordinates generator. Generators available: %s",
+			configuredCoordinatesGen, strings.Join(existingGenerators, ", ")))
+		log.Panic(err)
+	}
+
+	coordinates, err := coordinatesGenFactory(simConfigs, rngSeed)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	if coordinates.Enabled() {
+		util.Log.Infof(util.LogTag(networkLogTag)+"Nodes placed in the %d-dimensional coordinates' space: %d",
+			coordinates.dimensions, coordinates.numNodes())
+	}
+	return coordinates
+}
+
+// newNoCoordinates creates the coordinates of nodes without latency between them.
+func newNoCoordinates(simConfigs *configuration.Configuration, _ int64) (*Coordinates, error) {
+	return newCoordinates(simConfigs.TotalNumberOfNodes(), 0), nil
+}
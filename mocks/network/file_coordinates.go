@@ -0,0 +1,70 @@
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/util"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// newFileCoordinates reads the nodes' coordinates from a CSV file (e.g. embedded from a latency dataset), with
+// the coordinates of a node per row. When the file has fewer rows than nodes, the rows are reused.
+func newFileCoordinates(simConfigs *configuration.Configuration, _ int64) (*Coordinates, error) {
+	file, err := os.Open(simConfigs.NetworkFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("can't open the network coordinates file: %s", err)
+	}
+	defer file.Close()
+
+	dimensions := simConfigs.NetworkDimensions()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	points := make([]float64, 0)
+	skippedRows := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("can't read the network coordinates file: %s", err)
+		}
+		if len(record) < dimensions {
+			skippedRows++
+			continue
+		}
+		point := make([]float64, dimensions)
+		for d := range point {
+			if point[d], err = strconv.ParseFloat(strings.TrimSpace(record[d]), 64); err != nil {
+				break
+			}
+		}
+		if err != nil { // e.g. a header row.
+			skippedRows++
+			continue
+		}
+		points = append(points, point...)
+	}
+
+	if skippedRows > 0 {
+		util.Log.Warnf(util.LogTag(networkLogTag)+"Rows skipped without %d valid coordinates: %d", dimensions, skippedRows)
+	}
+	numRows := len(points) / dimensions
+	if numRows == 0 {
+		return nil, fmt.Errorf("the network coordinates file doesn't have valid coordinates")
+	}
+	if numRows < simConfigs.TotalNumberOfNodes() {
+		util.Log.Warnf(util.LogTag(networkLogTag)+"Coordinates read: %d, they are reused by the %d nodes",
+			numRows, simConfigs.TotalNumberOfNodes())
+	}
+
+	res := newCoordinates(simConfigs.TotalNumberOfNodes(), dimensions)
+	for node := 0; node < res.numNodes(); node++ {
+		copy(res.Point(node), points[(node%numRows)*dimensions:])
+	}
+	return res, nil
+}
@@ -0,0 +1,16 @@
+package network
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"math/rand"
+)
+
+// newRandomCoordinates places the nodes uniformly at random in the coordinates' space.
+func newRandomCoordinates(simConfigs *configuration.Configuration, rngSeed int64) (*Coordinates, error) {
+	randomGenerator := rand.New(rand.NewSource(rngSeed))
+	res := newCoordinates(simConfigs.TotalNumberOfNodes(), simConfigs.NetworkDimensions())
+	for i := range res.points {
+		res.points[i] = randomGenerator.Float64() * simConfigs.NetworkSpaceSize()
+	}
+	return res, nil
+}
@@ -0,0 +1,294 @@
+package chord
+
+import (
+	"github.com/ivpusic/grpool"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/util"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// churn holds the state of the nodes joining and leaving the ring during the simulation.
+type churn struct {
+	randomGenerator *rand.Rand                   // Pseudo-random generator used to choose the nodes.
+	active          []bool                       // Ring's positions (virtual nodes) that are in the ring.
+	numActive       int                          // Number of ring's positions (virtual nodes) in the ring.
+	nextFinger      []uint8                      // Next finger fixed by each node in the periodic repair.
+	joinsAcc        float64                      // Fraction of the joins that weren't done in the previous ticks.
+	leavesAcc       float64                      // Fraction of the leaves that weren't done in the previous ticks.
+	simConfigs      *configuration.Configuration // Simulator's configurations.
+}
+
+// InitChurn enables the nodes joining and leaving the ring during the simulation.
+// Only the initial percentage of the nodes is placed in the ring, the others can join it later.
+// A node joins and leaves the ring with all its virtual nodes.
+func (m *Mock) InitChurn(simConfigs *configuration.Configuration) {
+	m.churn = &churn{
+		randomGenerator: rand.New(rand.NewSource(m.rngSeed + 1)),
+		active:          make([]bool, len(m.ringMock)),
+		numActive:       len(m.ringMock),
+		nextFinger:      make([]uint8, len(m.ringMock)),
+		joinsAcc:        0,
+		leavesAcc:       0,
+		simConfigs:      simConfigs,
+	}
+	for i := range m.churn.active {
+		m.churn.active[i] = true
+		m.churn.nextFinger[i] = 1
+	}
+
+	numOutside := m.numNodes - int(math.Round(float64(m.numNodes)*simConfigs.ChordChurnInitialNodes()/100))
+	outside := 0
+	for _, nodeIndex := range m.churn.randomGenerator.Perm(m.numNodes) {
+		if outside == numOutside {
+			break
+		}
+		if nodeIndex == m.masterNodeIndex() {
+			continue // The first node (e.g. swarm's master) is always in the ring.
+		}
+		for _, ringIndex := range m.nodePositions(nodeIndex) {
+			m.churn.active[ringIndex] = false
+			m.churn.numActive--
+		}
+		outside++
+	}
+
+	// The finger's tables change with the churn, so they are stored (built only with the nodes in the ring).
+	fingersStart := time.Now()
+	goroutinePool := grpool.NewPool(runtime.NumCPU(), runtime.NumCPU()*5)
+	for i := range m.ringMock {
+		if !m.isActive(i) {
+			continue
+		}
+		tempIndex := i
+		goroutinePool.WaitCount(1)
+		goroutinePool.JobQueue <- func() {
+			defer goroutinePool.JobDone()
+			m.initFingers(tempIndex)
+		}
+	}
+	goroutinePool.WaitAll()
+	goroutinePool.Release()
+
+	util.Log.Infof(util.LogTag(chordLogTag)+"Churn enabled, nodes in the ring: %d, finger's tables built in %s",
+		m.churn.numActive/m.numVirtualNodes, time.Since(fingersStart))
+	m.logMemoryEstimate()
+}
+
+// Tick makes the nodes join and leave the ring, and repairs the ring if the repair is periodic.
+func (m *Mock) Tick(tick int) {
+	if m.churn == nil {
+		return
+	}
+
+	joinRate, leaveRate := m.churn.simConfigs.ChordChurnRates()
+	numLeaves := m.churnAmount(leaveRate, &m.churn.leavesAcc)
+	numJoins := m.churnAmount(joinRate, &m.churn.joinsAcc)
+
+	leaves, joins := 0, 0
+	for _, nodeIndex := range m.churn.randomGenerator.Perm(m.numNodes) {
+		if nodeIndex == m.masterNodeIndex() {
+			continue // The first node (e.g. swarm's master) never leaves.
+		}
+		positions := m.nodePositions(nodeIndex)
+		if m.churn.active[positions[0]] && leaves < numLeaves && m.churn.numActive > m.numSuccessors+len(positions) {
+			for _, ringIndex := range positions {
+				m.leave(int(ringIndex))
+			}
+			leaves++
+		} else if !m.churn.active[positions[0]] && joins < numJoins {
+			for _, ringIndex := range positions {
+				m.join(int(ringIndex))
+			}
+			joins++
+		}
+	}
+
+	if m.churn.simConfigs.ChordChurnRepair() == "periodic" && tick%m.churn.simConfigs.ChordChurnStabilizeTicks() == 0 {
+		m.stabilize()
+	}
+	util.Log.Debugf(util.LogTag(chordLogTag)+"Joins: %d, Leaves: %d, Nodes in the ring: %d", joins, leaves,
+		m.churn.numActive/m.numVirtualNodes)
+}
+
+// churnAmount returns the number of nodes that join/leave in the tick, accumulating the fractions between ticks.
+func (m *Mock) churnAmount(rate float64, acc *float64) int {
+	*acc += float64(m.numNodes) * rate / 100
+	res := int(*acc)
+	*acc -= float64(res)
+	return res
+}
+
+// leave removes the virtual node from the ring. The node notifies its predecessor and successor.
+func (m *Mock) leave(nodeIndex int) {
+	m.churn.active[nodeIndex] = false
+	m.churn.numActive--
+
+	successorIndex := m.activeSuccessorOfIndex(nodeIndex)
+	m.maintenanceMessage(nodeIndex, m.activePredecessorOfIndex(nodeIndex), metrics.NotifyMsg, m.msgSizes.notify)
+	m.maintenanceMessage(nodeIndex, successorIndex, metrics.NotifyMsg, m.msgSizes.notify)
+
+	if m.churn.simConfigs.ChordChurnRepair() == "instant" { // The fingers pointing to the node are updated.
+		for i := range m.ringMock {
+			if m.isActive(i) && m.ringMock[i].ReplaceFingers(nodeIndex, successorIndex) {
+				m.maintenanceMessage(nodeIndex, i, metrics.NotifyMsg, m.msgSizes.notify)
+			}
+		}
+	}
+}
+
+// join adds the virtual node into the ring. The node looks up its successor through a node in the ring.
+func (m *Mock) join(nodeIndex int) {
+	bootstrapIndex := m.activeSuccessorOfIndex(nodeIndex - 1)
+	m.churn.active[nodeIndex] = true
+	m.churn.numActive++
+	joiningNode := &m.ringMock[nodeIndex]
+	successorIndex := m.routeLookup(bootstrapIndex, joiningNode.id)
+
+	if m.churn.simConfigs.ChordChurnRepair() == "periodic" {
+		// The fingers are fixed by the following stabilizations.
+		joiningNode.fingers = []fingerSegment{{first: 0, index: int32(successorIndex)}}
+		m.churn.nextFinger[nodeIndex] = 1
+		return
+	}
+
+	// Instant repair: the joining node initializes its fingers and the other nodes' fingers are updated.
+	fingersIndexes := make([]int32, ringIDBits)
+	fingersIndexes[0] = int32(successorIndex)
+	fingerIndex := successorIndex
+	for finger := 1; finger < len(fingersIndexes); finger++ {
+		if target := joiningNode.FingerTarget(finger); !belongToIncludedTop(target, joiningNode.id, m.ringMock[fingerIndex].id) {
+			fingerIndex = m.routeLookup(successorIndex, target)
+		}
+		fingersIndexes[finger] = int32(m.proximityFinger(nodeIndex, finger, fingerIndex))
+	}
+	joiningNode.SetFingersIndexes(fingersIndexes)
+
+	predecessorID := m.ringMock[m.activePredecessorOfIndex(nodeIndex)].id
+	for i := range m.ringMock {
+		if !m.isActive(i) || i == nodeIndex {
+			continue
+		}
+		if m.ringMock[i].RedirectFingers(successorIndex, nodeIndex, predecessorID, joiningNode.id) {
+			m.maintenanceMessage(nodeIndex, i, metrics.NotifyMsg, m.msgSizes.notify)
+		}
+	}
+}
+
+// stabilize runs the chord's periodic stabilization in all the nodes of the ring: each node verifies its
+// successor and fixes some of its fingers.
+func (m *Mock) stabilize() {
+	fixFingers := m.churn.simConfigs.ChordChurnFixFingers()
+	for i := range m.ringMock {
+		if !m.isActive(i) {
+			continue
+		}
+		node := &m.ringMock[i]
+
+		// Ask the successor for its predecessor (the successor is the first finger in the ring).
+		knownSuccessor := m.activeSuccessorOfIndex(i)
+		for _, segment := range node.fingers {
+			if m.isActive(int(segment.index)) {
+				knownSuccessor = int(segment.index)
+				break
+			}
+		}
+		m.maintenanceMessage(i, knownSuccessor, metrics.StabilizeMsg, m.msgSizes.stabilize)
+		m.maintenanceMessage(knownSuccessor, i, metrics.StabilizeMsg, m.msgSizes.stabilizeResponse)
+		if successorIndex := m.activeSuccessorOfIndex(i); successorIndex != node.FingerIndex(0) {
+			node.SetFinger(0, successorIndex)
+			m.maintenanceMessage(i, successorIndex, metrics.NotifyMsg, m.msgSizes.notify)
+		}
+
+		for f := 0; f < fixFingers && ringIDBits > 1; f++ {
+			finger := int(m.churn.nextFinger[i])
+			node.SetFinger(finger, m.proximityFinger(i, finger, m.routeLookup(i, node.FingerTarget(finger))))
+			m.churn.nextFinger[i] = uint8(finger%(ringIDBits-1) + 1)
+		}
+	}
+}
+
+// routeLookup routes a maintenance lookup of the key starting in the given node, charging the messages to
+// the nodes in the route. It returns the index of the key's successor found.
+func (m *Mock) routeLookup(fromIndex int, key ringID) int {
+	currentIndex := fromIndex
+	for hops := 0; hops < len(m.ringMock); hops++ {
+		nextIndex, found := m.nextHop(currentIndex, key)
+		if nextIndex == currentIndex && !found { // No route (all the fingers left the ring).
+			break
+		}
+		m.maintenanceMessage(currentIndex, nextIndex, metrics.FindSuccessorMsg, m.msgSizes.findSuccessor)
+		if found {
+			m.maintenanceMessage(nextIndex, fromIndex, metrics.FindSuccessorMsg, m.msgSizes.findSuccessorResponse)
+			return nextIndex
+		}
+		currentIndex = nextIndex
+	}
+	return m.activeSuccessor(key)
+}
+
+// maintenanceMessage charges a ring's maintenance message, sent between the two nodes, to the nodes.
+func (m *Mock) maintenanceMessage(fromIndex, toIndex int, msgType metrics.MessageType, sizeBytes int64) {
+	m.collector.MaintenanceMessageReceived(m.ringMock[fromIndex].NodeIndex(), m.ringMock[toIndex].NodeIndex(), msgType, 1, sizeBytes)
+}
+
+// initFingers points all the node's fingers to the successors, in the ring, of the fingers' targets (or to
+// the closest candidates, see proximityFinger). The successor is only searched again when the finger's target
+// passes the previous finger's successor.
+func (m *Mock) initFingers(nodeIndex int) {
+	node := &m.ringMock[nodeIndex]
+	fingersIndexes := make([]int32, ringIDBits)
+	fingerIndex := -1
+	for finger := range fingersIndexes {
+		target := node.FingerTarget(finger)
+		if fingerIndex == -1 || !belongToIncludedTop(target, node.id, m.ringMock[fingerIndex].id) {
+			fingerIndex = m.activeSuccessor(target)
+		}
+		fingersIndexes[finger] = int32(m.proximityFinger(nodeIndex, finger, fingerIndex))
+	}
+	node.SetFingersIndexes(fingersIndexes)
+}
+
+// masterNodeIndex returns the index of the node that owns the GUID 0 (e.g. swarm's master).
+func (m *Mock) masterNodeIndex() int {
+	return m.ringMock[0].NodeIndex()
+}
+
+// isActive returns true if the virtual node is in the ring.
+func (m *Mock) isActive(nodeIndex int) bool {
+	return m.churn == nil || m.churn.active[nodeIndex]
+}
+
+// activeSuccessor returns the index of the key's successor between the nodes in the ring.
+func (m *Mock) activeSuccessor(key ringID) int {
+	index := sort.Search(len(m.ringMock), func(i int) bool {
+		return m.ringMock[i].id.Cmp(key) >= 0
+	})
+	return m.activeSuccessorOfIndex(index - 1)
+}
+
+// activeSuccessorOfIndex returns the index of the node's successor between the nodes in the ring.
+func (m *Mock) activeSuccessorOfIndex(nodeIndex int) int {
+	for i := 1; i <= len(m.ringMock); i++ {
+		successorIndex := (nodeIndex + i + len(m.ringMock)) % len(m.ringMock)
+		if m.isActive(successorIndex) {
+			return successorIndex
+		}
+	}
+	return nodeIndex
+}
+
+// activePredecessorOfIndex returns the index of the node's predecessor between the nodes in the ring.
+func (m *Mock) activePredecessorOfIndex(nodeIndex int) int {
+	for i := 1; i <= len(m.ringMock); i++ {
+		predecessorIndex := (nodeIndex - i + len(m.ringMock)) % len(m.ringMock)
+		if m.isActive(predecessorIndex) {
+			return predecessorIndex
+		}
+	}
+	return nodeIndex
+}
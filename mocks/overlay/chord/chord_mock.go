@@ -2,133 +2,173 @@ package chord
 
 import (
 	"context"
-	"github.com/ivpusic/grpool"
+	"crypto/sha1"
 	"github.com/pkg/errors"
 	"github.com/strabox/caravela-sim/engine/metrics"
 	"github.com/strabox/caravela-sim/mocks/caravela"
+	"github.com/strabox/caravela-sim/mocks/network"
+	"github.com/strabox/caravela-sim/mocks/wire"
 	"github.com/strabox/caravela-sim/util"
 	"github.com/strabox/caravela/api/types"
-	"github.com/strabox/caravela/node/common/guid"
 	"github.com/strabox/caravela/overlay"
 	"math/big"
-	"runtime"
+	"math/rand"
 	"sort"
+	"time"
+	"unsafe"
 )
 
 // chordLogTag chord's mock log tag.
 const chordLogTag = "SIM-CHORD"
 
+// lookupPathBufferSize number of the lookup's hops recorded without allocating memory.
+const lookupPathBufferSize = 64
+
 // Mock mocks the interactions with a Chord overlay client simulating its functionality.
 // All in memory, goroutine-safe.
 type Mock struct {
-	collector       *metrics.Collector // Metrics collector.
-	numSpeedupNodes int
-
-	numNodes      int // Initial number of nodes for the chord.
-	numSuccessors int // Number of successors for each chord node.
-
-	ringMock        []NodeMock     // Array that represent the node's chord ring.
-	nodesIdIndexMap map[string]int // ID <-> Index.
-	nodesIpIndexMap map[string]int // IP <-> Index.
+	collector *metrics.Collector // Metrics collector.
+
+	numNodes            int                  // Initial number of nodes for the chord.
+	numVirtualNodes     int                  // Number of ring's positions (virtual nodes) of each node.
+	numSuccessors       int                  // Number of successors for each chord node.
+	proximityCandidates int                  // Candidates considered for each finger (0 disables the PNS).
+	guidPlacement       string               // Placement of the nodes' GUIDs in the ring: uniform or hashed.
+	coordinates         *network.Coordinates // Network coordinates of the nodes (latency between them).
+	sizes               wire.SizeModel       // Measures the size of the messages.
+	rngSeed             int64                // Seed used to generate the nodes' identities in the hashed placement.
+	msgSizes            messagesSizes        // Size of each chord's message.
+
+	ringMock        []NodeMock     // Array that represent the node's chord ring (a position per virtual node).
+	nodesPositions  []int32        // Indexes of the node's positions in the ring (numVirtualNodes per node).
+	nodesIPs        []string       // Node's Index <-> IP.
+	nodesIpIndexMap map[string]int // IP <-> Node's Index.
+
+	churn *churn // State of the nodes joining and leaving the ring (nil if the ring is static).
 }
 
 // NewChordMock creates a new chord overlay that can be used by an application component.
 // It implements the github.com/strabox/caravela/node/external Overlay interface.
-func NewChordMock(numNodes, numSuccessors, numSpeedupNodes int, metricsCollector *metrics.Collector) *Mock {
+func NewChordMock(numNodes, numVirtualNodes, numSuccessors, proximityCandidates int, guidPlacement string,
+	coordinates *network.Coordinates, sizeModel wire.SizeModel, rngSeed int64, metricsCollector *metrics.Collector) *Mock {
 	return &Mock{
-		collector:       metricsCollector,
-		numSpeedupNodes: numSpeedupNodes,
-		numNodes:        numNodes,
-		numSuccessors:   numSuccessors,
-		ringMock:        make([]NodeMock, numNodes),
-		nodesIdIndexMap: make(map[string]int),
-		nodesIpIndexMap: make(map[string]int),
+		collector:           metricsCollector,
+		numNodes:            numNodes,
+		numVirtualNodes:     numVirtualNodes,
+		numSuccessors:       numSuccessors,
+		proximityCandidates: proximityCandidates,
+		guidPlacement:       guidPlacement,
+		coordinates:         coordinates,
+		sizes:               sizeModel,
+		rngSeed:             rngSeed,
+		ringMock:            make([]NodeMock, numNodes*numVirtualNodes),
+		nodesPositions:      make([]int32, numNodes*numVirtualNodes),
+		nodesIPs:            make([]string, numNodes),
+		nodesIpIndexMap:     make(map[string]int, numNodes),
 	}
 }
 
 // Init initializes the chord's mock structure.
+// The finger's tables of a static ring aren't stored, the fingers are computed on demand from the sorted ring.
 func (m *Mock) Init() {
-	// Generate the Node's ID uniformly in order to easily do the perfect chord route mechanism with minimal overhead.
-	maxNumGUIDs := maxNumGUIDs()
-	nodeGUIDGen := big.NewInt(0)
-	nodeGUIDSpace := maxNumGUIDs.Div(maxNumGUIDs, big.NewInt(int64(m.numNodes)))
-	for i := 0; i < m.numNodes; i++ {
-		m.ringMock[i] = *NewNodeRandomIP(nodeGUIDGen)
-		nodeGUIDGen.Add(nodeGUIDGen, nodeGUIDSpace)
+	initStart := time.Now()
+	for i := range m.nodesIPs {
+		m.nodesIPs[i] = util.RandomIP()
+		m.nodesIpIndexMap[m.nodesIPs[i]] = i
+	}
+
+	if m.guidPlacement == "hashed" {
+		m.hashedGUIDs()
+	} else {
+		m.uniformGUIDs()
 	}
 
 	// Sort nodes by ID (ascending order)
 	sort.Sort(m)
+	m.msgSizes = newMessagesSizes(m.sizes, len(m.ringMock[0].Bytes()))
 
-	// Use of speed up nodes in order quickly fill the finger's tables.
-	speedupNodes := make([]speedupNodeMock, m.numSpeedupNodes) // Array of speed up nodes.
-	speedupDivSize := m.numNodes / m.numSpeedupNodes
-	divSize := 1
-	speedupNodeIndex := 0
-	for i := 0; i < m.numNodes; i++ {
-		m.nodesIdIndexMap[m.ringMock[i].String()] = i
-		m.nodesIpIndexMap[m.ringMock[i].IP()] = i
-
-		if speedupDivSize != 0 && divSize == speedupDivSize && speedupNodeIndex < m.numSpeedupNodes {
-			speedupNodes[speedupNodeIndex] = *newSpeedupNodeMock(i, &m.ringMock[i])
-			speedupNodeIndex++
-			divSize = 1
-			continue
-		}
-		divSize++
+	nodesNumPositions := make([]int32, m.numNodes)
+	for i := range m.ringMock {
+		nodeIndex := m.ringMock[i].NodeIndex()
+		m.nodesPositions[nodeIndex*m.numVirtualNodes+int(nodesNumPositions[nodeIndex])] = int32(i)
+		nodesNumPositions[nodeIndex]++
 	}
 
-	successorNodeIndex := func(searchMockNode *NodeMock) int {
-		// Simulate the lookup using the chord with the array
-		startSearchIndex := 0
-		// Speeding up the successor lookup using the special pointer nodes (Speedup Nodes).
-		if len(m.ringMock) >= m.numSpeedupNodes {
-			for index, node := range speedupNodes {
-				if index > 0 {
-					startSearchIndex = speedupNodes[index-1].index
-				}
-				if searchMockNode.Smaller(node.NodeMock) {
-					break
-				}
-			}
-		}
-
-		// Regular sequential search for the node in the ring.
-		for i := startSearchIndex; i < len(m.ringMock); i++ {
-			currentNode := m.ringMock[i]
-			if currentNode.HigherOrEqual(searchMockNode) {
-				return i
-			}
-		}
+	util.Log.Infof(util.LogTag(chordLogTag)+"Ring initialized in %s", time.Since(initStart))
+	m.Print()
+	m.logMemoryEstimate()
+}
 
-		return 0
+// uniformGUIDs generates the Node's ID uniformly in order to easily do the perfect chord route mechanism with
+// minimal overhead. The virtual nodes of each node are spread evenly in the ring.
+func (m *Mock) uniformGUIDs() {
+	maxNumGUIDs := big.NewInt(0).Lsh(big.NewInt(1), uint(ringIDBits))
+	nodeGUIDSpace := newRingID(maxNumGUIDs.Div(maxNumGUIDs, big.NewInt(int64(len(m.ringMock)))))
+	nodeGUIDGen := ringID{}
+	for i := range m.ringMock {
+		m.ringMock[i] = *NewNodeMock(nodeGUIDGen, i%m.numNodes)
+		nodeGUIDGen = nodeGUIDGen.Add(nodeGUIDSpace)
 	}
+}
 
-	// Goroutine pool used to build the finger's tables faster.
-	goroutinePool := grpool.NewPool(runtime.NumCPU(), runtime.NumCPU()*5)
+// hashedGUIDs generates the Node's ID hashing a random identity for each virtual node, like a real chord
+// deployment, so the ring's arcs owned by the nodes are unbalanced.
+func (m *Mock) hashedGUIDs() {
+	randomGenerator := rand.New(rand.NewSource(m.rngSeed))
+	identity := make([]byte, 16)
+	for i := range m.ringMock {
+		randomGenerator.Read(identity)
+		hash := sha1.Sum(identity)
+		nodeGUID := newRingIDBytes(hash[:])
+		if i == 0 { // The swarm backend needs the first node (master) with the GUID 0.
+			nodeGUID = ringID{}
+		}
+		m.ringMock[i] = *NewNodeMock(nodeGUID, i%m.numNodes)
+	}
+}
 
+// ArcLengths returns the fraction of the ring's GUIDs owned by each node, i.e. the sum of the arcs between
+// each of the node's virtual nodes and its predecessor.
+func (m *Mock) ArcLengths() []float64 {
+	res := make([]float64, m.numNodes)
 	for i := range m.ringMock {
-		ringNode := &m.ringMock[i]
-		goroutinePool.WaitCount(1)
-		goroutinePool.JobQueue <- func() {
-			defer goroutinePool.JobDone()
-			fingerTable := ringNode.FingerTable()
-			fingersIndexes := make([]int, len(fingerTable))
-			fingersGUIDs := make([]*guid.GUID, len(fingerTable))
-			for k, fingerGUID := range fingerTable {
-				successorIndex := successorNodeIndex(NewNodeBytes(fingerGUID.Bytes()))
-				fingersIndexes[k] = successorIndex
-				fingersGUIDs[k] = m.ringMock[successorIndex].guid
-			}
-			ringNode.SetFingersNodeIndexes(fingersIndexes, fingersGUIDs)
+		predecessor := m.ringMock[(i-1+len(m.ringMock))%len(m.ringMock)].id
+		arcLength := m.ringMock[i].id.Sub(predecessor).Fraction()
+		if len(m.ringMock) == 1 {
+			arcLength = 1
 		}
+		res[m.ringMock[i].NodeIndex()] += arcLength
 	}
+	return res
+}
 
-	goroutinePool.WaitAll() // Wait for all the plots to be completed.
-	goroutinePool.Release() // Release goroutinePool resources.
-	goroutinePool = nil     // Only to ensure madness errors.
+// MemoryEstimate returns an estimate of the memory (in bytes) used by the ring and the finger's tables.
+func (m *Mock) MemoryEstimate() int64 {
+	res := int64(len(m.ringMock)) * int64(unsafe.Sizeof(NodeMock{}))
+	res += int64(m.numFingerSegments()) * int64(unsafe.Sizeof(fingerSegment{}))
+	res += int64(len(m.nodesPositions)) * int64(unsafe.Sizeof(int32(0)))
+	for _, ip := range m.nodesIPs {
+		// Each IP is stored in the array and as the map's key (the map's buckets double the entries' size).
+		res += int64(len(ip)) + int64(unsafe.Sizeof(ip)) + 2*int64(unsafe.Sizeof(ip)+unsafe.Sizeof(0))
+	}
+	if m.churn != nil {
+		res += int64(len(m.churn.active)) * int64(unsafe.Sizeof(false)+unsafe.Sizeof(uint8(0)))
+	}
+	return res
+}
 
-	m.Print()
+// numFingerSegments returns the number of finger's runs stored in the ring.
+func (m *Mock) numFingerSegments() int {
+	res := 0
+	for i := range m.ringMock {
+		res += len(m.ringMock[i].fingers)
+	}
+	return res
+}
+
+func (m *Mock) logMemoryEstimate() {
+	util.Log.Infof(util.LogTag(chordLogTag)+"Ring's memory estimate: %.2f MB (Positions: %d, Finger's runs: %d)",
+		float64(m.MemoryEstimate())/(1024*1024), len(m.ringMock), m.numFingerSegments())
 }
 
 func (m *Mock) Print() {
@@ -136,17 +176,34 @@ func (m *Mock) Print() {
 	util.Log.Debugf("#                   CHORD's MOCK CONFIGURATIONS                  #")
 	util.Log.Debugf("##################################################################")
 	util.Log.Debugf("Nodes:              	%d", m.numNodes)
-	util.Log.Debugf("Speedup Nodes:      	%d", m.numSpeedupNodes)
-	util.Log.Debugf("Speedup Window Size: 	%d", m.numNodes/m.numSpeedupNodes)
+	util.Log.Debugf("Virtual Nodes:      	%d", m.numVirtualNodes)
+	util.Log.Debugf("GUID Placement:      	%s", m.guidPlacement)
+	util.Log.Debugf("Proximity Candidates:	%d", m.proximityCandidates)
 	util.Log.Debugf("##################################################################")
 }
 
+// GetNodeMockByIndex returns the node's first virtual node, given the node's index.
 func (m *Mock) GetNodeMockByIndex(index int) *NodeMock {
-	return &m.ringMock[index]
+	return &m.ringMock[m.nodesPositions[index*m.numVirtualNodes]]
+}
+
+// GetNodeVirtualMocks returns all the virtual nodes of the node, given the node's index.
+func (m *Mock) GetNodeVirtualMocks(index int) []*NodeMock {
+	positions := m.nodePositions(index)
+	res := make([]*NodeMock, len(positions))
+	for i, ringIndex := range positions {
+		res[i] = &m.ringMock[ringIndex]
+	}
+	return res
 }
 
+// GetNodeMockByGUID returns the virtual node, and its index in the ring, given the virtual node's GUID.
 func (m *Mock) GetNodeMockByGUID(guid string) (int, *NodeMock) {
-	index := m.nodesIdIndexMap[guid]
+	id, valid := parseRingID(guid)
+	if !valid {
+		panic(errors.New("Invalid node's GUID!"))
+	}
+	index := m.ringIndex(id)
 	return index, &m.ringMock[index]
 }
 
@@ -155,7 +212,129 @@ func (m *Mock) GetNodeMockByIP(ip string) (int, *NodeMock) {
 	if !exist {
 		panic(errors.New("Node's IP does not exist in the system!"))
 	}
-	return index, &m.ringMock[index]
+	return index, m.GetNodeMockByIndex(index)
+}
+
+// NodeIP returns the IP of the node with the given index.
+func (m *Mock) NodeIP(nodeIndex int) string {
+	return m.nodesIPs[nodeIndex]
+}
+
+// NodeTradersGUIDs returns the GUIDs of the node's virtual nodes, the first virtual node is the last one.
+func (m *Mock) NodeTradersGUIDs(nodeIndex int) [][]byte {
+	virtualNodesMocks := m.GetNodeVirtualMocks(nodeIndex)
+	res := make([][]byte, len(virtualNodesMocks))
+	for i := range virtualNodesMocks {
+		res[len(res)-1-i] = virtualNodesMocks[i].Bytes()
+	}
+	return res
+}
+
+// NodeIndexByIP returns the index of the node with the given IP.
+func (m *Mock) NodeIndexByIP(ip string) int {
+	index, _ := m.GetNodeMockByIP(ip)
+	return index
+}
+
+// NodeIndexByGUID returns the index of the node that owns the virtual node with the given GUID.
+func (m *Mock) NodeIndexByGUID(guid string) int {
+	_, nodeMock := m.GetNodeMockByGUID(guid)
+	return nodeMock.NodeIndex()
+}
+
+// nodePositions returns the indexes of the node's positions (virtual nodes) in the ring, in the ring's order.
+func (m *Mock) nodePositions(nodeIndex int) []int32 {
+	return m.nodesPositions[nodeIndex*m.numVirtualNodes : (nodeIndex+1)*m.numVirtualNodes]
+}
+
+// ringIndex returns the index in the ring of the virtual node with the given GUID.
+func (m *Mock) ringIndex(id ringID) int {
+	index := sort.Search(len(m.ringMock), func(i int) bool {
+		return m.ringMock[i].id.Cmp(id) >= 0
+	})
+	if index == len(m.ringMock) || m.ringMock[index].id != id {
+		panic(errors.New("Node's GUID does not exist in the system!"))
+	}
+	return index
+}
+
+// overlayNode returns the overlay's representation of the virtual node.
+func (m *Mock) overlayNode(ringIndex int) *overlay.OverlayNode {
+	return overlay.NewOverlayNode(m.nodesIPs[m.ringMock[ringIndex].NodeIndex()], caravela.FakePort,
+		m.ringMock[ringIndex].Bytes())
+}
+
+// nextHop returns the next node in the route, from the given node, to the key's successor and true if it is
+// the key's successor. The fingers pointing to nodes that left the ring are skipped, the first active finger
+// is used as the node's successor (like a chord's successor list).
+func (m *Mock) nextHop(ringIndex int, key ringID) (int, bool) {
+	node := &m.ringMock[ringIndex]
+	if m.churn == nil {
+		// Static ring: the successor is the next position and each finger is the successor of its target (or
+		// the closest candidate to the node, see proximityFinger).
+		successor := (ringIndex + 1) % len(m.ringMock)
+		if belongToIncludedTop(key, node.id, m.ringMock[successor].id) {
+			return successor, true
+		}
+		// The fingers whose target is after the key can't precede it.
+		for finger := key.Sub(node.id).BitLen() - 1; finger >= 0; finger-- {
+			fingerIndex := m.proximityFinger(ringIndex, finger, m.activeSuccessor(node.FingerTarget(finger)))
+			if belongToExcludedLimits(m.ringMock[fingerIndex].id, node.id, key) {
+				return fingerIndex, false
+			}
+		}
+		return ringIndex, false
+	}
+
+	successor := -1
+	for _, segment := range node.fingers {
+		if m.isActive(int(segment.index)) {
+			successor = int(segment.index)
+			break
+		}
+	}
+	if successor == -1 {
+		return ringIndex, false
+	}
+
+	if belongToIncludedTop(key, node.id, m.ringMock[successor].id) {
+		return successor, true
+	}
+	for i := len(node.fingers) - 1; i >= 0; i-- {
+		fingerIndex := int(node.fingers[i].index)
+		if m.isActive(fingerIndex) && belongToExcludedLimits(m.ringMock[fingerIndex].id, node.id, key) {
+			return fingerIndex, false
+		}
+	}
+	return ringIndex, false
+}
+
+// proximityFinger returns the node chosen for the finger by the proximity neighbour selection (PNS): any node
+// in the finger's interval [node + 2^finger, node + 2^(finger+1)[ is a valid finger, so the node with the lowest
+// latency among the first proximityCandidates nodes of the interval, starting in the finger target's successor,
+// is chosen. Without PNS, or for the successor's finger, it returns the finger target's successor.
+func (m *Mock) proximityFinger(ringIndex, finger, successorIndex int) int {
+	if m.proximityCandidates == 0 || finger == 0 {
+		return successorIndex
+	}
+	nodeID := m.ringMock[ringIndex].id
+	res, resLatency := successorIndex, m.latency(ringIndex, successorIndex)
+	candidateIndex := successorIndex
+	for candidate := 1; candidate < m.proximityCandidates; candidate++ {
+		candidateIndex = m.activeSuccessorOfIndex(candidateIndex)
+		if candidateIndex == ringIndex || m.ringMock[candidateIndex].id.Sub(nodeID).BitLen() > finger+1 {
+			break // The candidate is outside of the finger's interval.
+		}
+		if latency := m.latency(ringIndex, candidateIndex); latency < resLatency {
+			res, resLatency = candidateIndex, latency
+		}
+	}
+	return res
+}
+
+// latency returns the latency (ms) between the (physical) nodes of the two ring's positions.
+func (m *Mock) latency(ringIndexA, ringIndexB int) float64 {
+	return m.coordinates.Latency(m.ringMock[ringIndexA].NodeIndex(), m.ringMock[ringIndexB].NodeIndex())
 }
 
 // ===============================================================================
@@ -177,34 +356,53 @@ func (m *Mock) Lookup(ctx context.Context, key []byte) ([]*overlay.OverlayNode,
 	if fromNodeGUID == "" {
 		panic("Lookup message did not have the from node GUID debug data!")
 	}
-	currentNodeSearchIndex, _ := m.GetNodeMockByGUID(fromNodeGUID)
+	currentNodeSearchIndex, fromNode := m.GetNodeMockByGUID(fromNodeGUID)
+	if !m.isActive(currentNodeSearchIndex) { // The node left the ring, so the lookup starts in its successor.
+		currentNodeSearchIndex = m.activeSuccessorOfIndex(currentNodeSearchIndex)
+	}
 	found := false
 	messagesPerReqAcc := 0
+	latencyAcc := 0.0
+	var pathBuffer [lookupPathBufferSize]int // The path only escapes to the heap in the longest lookups.
+	path := pathBuffer[:0]
 
-	keyBigInt := big.NewInt(0)
-	keyBigInt.SetBytes(key)
-	fromBigInt := big.NewInt(0)
-	fromBigInt.SetString(fromNodeGUID, 10)
-	if keyBigInt.Cmp(fromBigInt) != 0 {
+	keyID := newRingIDBytes(key)
+	if keyID != fromNode.id {
 		for {
-			currentNodeSearchIndex, found = m.ringMock[currentNodeSearchIndex].Lookup(currentNodeSearchIndex, key)
+			var nextNodeSearchIndex int
+			nextNodeSearchIndex, found = m.nextHop(currentNodeSearchIndex, keyID)
+			if nextNodeSearchIndex == currentNodeSearchIndex && !found { // No route (all the fingers left the ring).
+				nextNodeSearchIndex, found = m.activeSuccessor(keyID), true
+			}
+			latencyAcc += m.latency(currentNodeSearchIndex, nextNodeSearchIndex)
+			m.collector.LookupMessageReceived(m.ringMock[currentNodeSearchIndex].NodeIndex(),
+				m.ringMock[nextNodeSearchIndex].NodeIndex(), metrics.FindSuccessorMsg, 1, m.msgSizes.findSuccessor)
+			currentNodeSearchIndex = nextNodeSearchIndex
 			messagesPerReqAcc++
-			m.collector.MessageReceived(currentNodeSearchIndex, 1, findSuccessorMessageSizeREST)
+			path = append(path, m.ringMock[currentNodeSearchIndex].NodeIndex())
 			if found {
 				// Reply to the node that called the Lookup.
 				messagesPerReqAcc++
-				fromNodeIndex, _ := m.GetNodeMockByGUID(fromNodeGUID)
-				m.collector.MessageReceived(fromNodeIndex, 1, findSuccessorMessageResponseSizeREST)
+				latencyAcc += m.coordinates.Latency(m.ringMock[currentNodeSearchIndex].NodeIndex(), fromNode.NodeIndex())
+				m.collector.LookupMessageReceived(m.ringMock[currentNodeSearchIndex].NodeIndex(), fromNode.NodeIndex(),
+					metrics.FindSuccessorMsg, 1, m.msgSizes.findSuccessorResponse)
 				m.collector.IncrMessagesTradedRequest(types.RequestID(ctx), messagesPerReqAcc)
+				if m.churn != nil && currentNodeSearchIndex != m.activeSuccessor(keyID) {
+					m.collector.OverlayStaleLookup() // The ring wasn't repaired yet.
+				}
 				break
 			}
 		}
 	}
+	m.collector.LookupDone(fromNode.NodeIndex(), path, latencyAcc)
 
 	res := make([]*overlay.OverlayNode, m.numSuccessors)
 	successorsFound := 0
 	for i := currentNodeSearchIndex; i < len(m.ringMock); i++ {
-		res[successorsFound] = overlay.NewOverlayNode(m.ringMock[i].IP(), caravela.FakePort, m.ringMock[i].Bytes())
+		if !m.isActive(i) {
+			continue
+		}
+		res[successorsFound] = m.overlayNode(i)
 		successorsFound++
 		if successorsFound == m.numSuccessors {
 			break
@@ -213,30 +411,25 @@ func (m *Mock) Lookup(ctx context.Context, key []byte) ([]*overlay.OverlayNode,
 
 	if successorsFound != m.numSuccessors {
 		for i := 0; i < len(m.ringMock); i++ {
-			res[successorsFound] = overlay.NewOverlayNode(m.ringMock[i].IP(), caravela.FakePort, m.ringMock[i].Bytes())
+			if !m.isActive(i) {
+				continue
+			}
+			res[successorsFound] = m.overlayNode(i)
 			successorsFound++
 			if successorsFound == m.numSuccessors {
 				break
 			}
 		}
 	}
-	return res, nil
+	return res[:successorsFound], nil
 }
 
 func (m *Mock) Neighbors(_ context.Context, nodeID []byte) ([]*overlay.OverlayNode, error) {
 	res := make([]*overlay.OverlayNode, 2)
-	neighMockNode := NewNodeBytes(nodeID)
-	index := m.nodesIdIndexMap[neighMockNode.String()]
-	if index == 0 {
-		res[0] = overlay.NewOverlayNode(m.ringMock[m.numNodes-1].IP(), caravela.FakePort, m.ringMock[m.numNodes-1].Bytes())
-		res[1] = overlay.NewOverlayNode(m.ringMock[1].IP(), caravela.FakePort, m.ringMock[1].Bytes())
-	} else if index == m.numNodes-1 {
-		res[0] = overlay.NewOverlayNode(m.ringMock[m.numNodes-2].IP(), caravela.FakePort, m.ringMock[m.numNodes-2].Bytes())
-		res[1] = overlay.NewOverlayNode(m.ringMock[0].IP(), caravela.FakePort, m.ringMock[0].Bytes())
-	} else {
-		res[0] = overlay.NewOverlayNode(m.ringMock[index-1].IP(), caravela.FakePort, m.ringMock[index-1].Bytes())
-		res[1] = overlay.NewOverlayNode(m.ringMock[index+1].IP(), caravela.FakePort, m.ringMock[index+1].Bytes())
-	}
+	index := m.ringIndex(newRingIDBytes(nodeID))
+	predecessorIndex, successorIndex := m.activePredecessorOfIndex(index), m.activeSuccessorOfIndex(index)
+	res[0] = m.overlayNode(predecessorIndex)
+	res[1] = m.overlayNode(successorIndex)
 	return res, nil
 }
 
@@ -1,6 +1,68 @@
 package chord
 
-// Num + Key + Node ID + IP address == 100 bytes (REST) == 4 bytes + 16 bytes + 16 bytes + 4bytes (binary) = 40bytes
-const findSuccessorMessageSizeREST = int64(100)
+import (
+	"github.com/strabox/caravela-sim/mocks/wire"
+)
 
-const findSuccessorMessageResponseSizeREST = int64(30)
+// Types of the messages traded between the chord's nodes, used by the message's size models (e.g. the names of
+// the message's types in the fixed size model's configuration).
+const (
+	findSuccessorMsgType         = "FindSuccessor"
+	findSuccessorResponseMsgType = "FindSuccessorResponse"
+	stabilizeMsgType             = "Stabilize"
+	stabilizeResponseMsgType     = "StabilizeResponse"
+	notifyMsgType                = "Notify"
+)
+
+// Size (bytes) of the chord's messages in the REST encoding, estimated by the simulator and kept by the json
+// size model.
+const (
+	// Num + Key + Node ID + IP address == 100 bytes (REST) == 4 bytes + 16 bytes + 16 bytes + 4bytes (binary) = 40bytes
+	findSuccessorMessageSizeREST         = 100
+	findSuccessorMessageResponseSizeREST = 30
+	stabilizeMessageSizeREST             = 60 // Node ID + IP address == 60 bytes (REST)
+	stabilizeMessageResponseSizeREST     = 60 // Predecessor's Node ID + IP address == 60 bytes (REST)
+	notifyMessageSizeREST                = 60 // Node ID + IP address == 60 bytes (REST)
+)
+
+// findSuccessorMsg is the payload of a find successor's request.
+type findSuccessorMsg struct {
+	Num    int    `json:"Num"`
+	Key    []byte `json:"Key"`
+	NodeID []byte `json:"NodeID"`
+	IP     string `json:"IP"`
+}
+
+// nodeMsg is the payload of the messages that only carry a node (the find successor's response, the
+// stabilize's request and response, and the notify).
+type nodeMsg struct {
+	NodeID []byte `json:"NodeID"`
+	IP     string `json:"IP"`
+}
+
+// messagesSizes holds the size (bytes) of each chord's message.
+type messagesSizes struct {
+	findSuccessor         int64
+	findSuccessorResponse int64
+	stabilize             int64
+	stabilizeResponse     int64
+	notify                int64
+}
+
+// newMessagesSizes measures the chord's messages, whose nodes' GUIDs have the given size (bytes). The messages
+// only carry fixed size IDs and IPs, so they are measured once, with representative IDs and IPs, instead of in
+// each lookup.
+func newMessagesSizes(sizes wire.SizeModel, idSize int) messagesSizes {
+	nodeID := wire.RepresentativeID(idSize)
+	node := &nodeMsg{NodeID: nodeID, IP: wire.RepresentativeIP}
+	findSuccessor := &findSuccessorMsg{Num: 1, Key: nodeID, NodeID: nodeID, IP: wire.RepresentativeIP}
+	return messagesSizes{
+		findSuccessor: int64(wire.EstimatedSize(sizes, findSuccessorMsgType, findSuccessor,
+			findSuccessorMessageSizeREST)),
+		findSuccessorResponse: int64(wire.EstimatedSize(sizes, findSuccessorResponseMsgType, node,
+			findSuccessorMessageResponseSizeREST)),
+		stabilize:         int64(wire.EstimatedSize(sizes, stabilizeMsgType, node, stabilizeMessageSizeREST)),
+		stabilizeResponse: int64(wire.EstimatedSize(sizes, stabilizeResponseMsgType, node, stabilizeMessageResponseSizeREST)),
+		notify:            int64(wire.EstimatedSize(sizes, notifyMsgType, node, notifyMessageSizeREST)),
+	}
+}
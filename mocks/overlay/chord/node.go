@@ -1,139 +1,140 @@
 package chord
 
 import (
-	"github.com/strabox/caravela-sim/util"
 	"github.com/strabox/caravela/node/common/guid"
-	"math/big"
 )
 
-var (
-	preCalculatedFingerOffset []*big.Int = nil
-	maxNumIDs                 *big.Int   = nil
-)
-
-type fingerMock struct {
-	fingerID *guid.GUID
-	simIndex int
+// fingerSegment is a run of consecutive fingers, starting in the first finger, that point to the same node.
+// The fingers of a node point to a few distinct nodes (~log(N)), so the runs keep the finger's tables small.
+type fingerSegment struct {
+	first uint8 // First finger of the run.
+	index int32 // Ring's index of the node pointed by the run's fingers.
 }
 
 type NodeMock struct {
-	guid        *guid.GUID
-	ip          string
-	fingerTable []fingerMock
+	id        ringID          // Position of the node in the ring.
+	nodeIndex int32           // Index of the (physical) node that owns this position of the ring.
+	fingers   []fingerSegment // Finger's table (nil when the fingers are computed on demand from the ring).
 }
 
-func NewNodeBytes(idBytes []byte) *NodeMock {
+func NewNodeMock(id ringID, nodeIndex int) *NodeMock {
 	return &NodeMock{
-		guid: guid.NewGUIDBytes(idBytes),
-		ip:   "",
+		id:        id,
+		nodeIndex: int32(nodeIndex),
+		fingers:   nil,
 	}
 }
 
-func Init(guidSizeBits int) {
-	preCalculatedFingerOffset = make([]*big.Int, guidSizeBits)
-	for i := range preCalculatedFingerOffset {
-		fingerOffset := big.NewInt(0)
-		fingerOffset.Exp(big.NewInt(2), big.NewInt(int64(i)), nil)
-		preCalculatedFingerOffset[i] = fingerOffset
-	}
-
-	maxNumIDs = big.NewInt(0)
-	maxNumIDs.Exp(big.NewInt(2), big.NewInt(int64(guidSizeBits)), nil)
+// FingerTarget returns the GUID that the finger should point to (node's GUID + 2^finger).
+func (n *NodeMock) FingerTarget(finger int) ringID {
+	return n.id.Add(powerOfTwo(finger))
 }
 
-func maxNumGUIDs() *big.Int {
-	maxNumGUIDs := big.NewInt(0)
-	maxNumGUIDs.Set(maxNumIDs)
-	return maxNumGUIDs
-}
-
-func NewNodeRandomIP(guidBigInt *big.Int) *NodeMock {
-	res := &NodeMock{
-		guid:        guid.NewGUIDBigInt(guidBigInt),
-		ip:          util.RandomIP(),
-		fingerTable: make([]fingerMock, guid.SizeBits()),
+// FingerIndex returns the index of the node pointed by the finger.
+func (n *NodeMock) FingerIndex(finger int) int {
+	for i := len(n.fingers) - 1; i >= 0; i-- {
+		if int(n.fingers[i].first) <= finger {
+			return int(n.fingers[i].index)
+		}
 	}
+	return -1
+}
 
-	for i := range res.fingerTable {
-		newFinger := big.NewInt(0)
-		tempBigInt := big.NewInt(0)
-		tempBigInt.Add(res.guid.BigInt(), preCalculatedFingerOffset[i])
-		newFinger.Mod(tempBigInt, maxNumIDs)
-		res.fingerTable[i].fingerID = guid.NewGUIDBigInt(newFinger)
+// FingersIndexes returns the index of the node pointed by each finger.
+func (n *NodeMock) FingersIndexes() []int32 {
+	res := make([]int32, ringIDBits)
+	for i, segment := range n.fingers {
+		last := ringIDBits
+		if i < len(n.fingers)-1 {
+			last = int(n.fingers[i+1].first)
+		}
+		for finger := int(segment.first); finger < last; finger++ {
+			res[finger] = segment.index
+		}
 	}
 	return res
 }
 
-func (n *NodeMock) SetFingersNodeIndexes(fingersIndexes []int, fingersGUIDs []*guid.GUID) {
-	for i := range n.fingerTable {
-		n.fingerTable[i].simIndex = fingersIndexes[i]
-		n.fingerTable[i].fingerID = fingersGUIDs[i]
+// SetFingersIndexes points each finger to the node with the given index, merging the consecutive fingers.
+func (n *NodeMock) SetFingersIndexes(fingersIndexes []int32) {
+	numSegments := 0
+	for finger := range fingersIndexes {
+		if finger == 0 || fingersIndexes[finger] != fingersIndexes[finger-1] {
+			numSegments++
+		}
 	}
-}
-
-func (n *NodeMock) FingerTable() []*guid.GUID {
-	res := make([]*guid.GUID, len(n.fingerTable))
-	for i := range res {
-		res[i] = n.fingerTable[i].fingerID
+	n.fingers = make([]fingerSegment, 0, numSegments)
+	for finger, index := range fingersIndexes {
+		if finger == 0 || index != fingersIndexes[finger-1] {
+			n.fingers = append(n.fingers, fingerSegment{first: uint8(finger), index: index})
+		}
 	}
-	return res
 }
 
-func (n *NodeMock) belongToExcludedLimits(key, bottom, top *big.Int) bool {
-	if bottom.Cmp(top) > 0 {
-		return (key.Cmp(top) < 0) || (key.Cmp(bottom) > 0)
-	} else {
-		return (key.Cmp(top) < 0) && (key.Cmp(bottom) > 0)
+// SetFinger points the finger to the node with the given index.
+func (n *NodeMock) SetFinger(finger, index int) {
+	if n.FingerIndex(finger) == index {
+		return
 	}
-}
-
-func (n *NodeMock) belongToIncludedTop(key, bottom, top *big.Int) bool {
-	if bottom.Cmp(top) > 0 {
-		return (key.Cmp(top) <= 0) || (key.Cmp(bottom) > 0)
-	} else {
-		return (key.Cmp(top) <= 0) && (key.Cmp(bottom) > 0)
+	fingersIndexes := n.FingersIndexes()
+	fingersIndexes[finger] = int32(index)
+	n.SetFingersIndexes(fingersIndexes)
+}
+
+// ReplaceFingers points the fingers that point to the old node to the new node. It returns true if any finger
+// was updated.
+func (n *NodeMock) ReplaceFingers(oldIndex, newIndex int) bool {
+	for _, segment := range n.fingers {
+		if int(segment.index) == oldIndex {
+			fingersIndexes := n.FingersIndexes()
+			for finger := range fingersIndexes {
+				if int(fingersIndexes[finger]) == oldIndex {
+					fingersIndexes[finger] = int32(newIndex)
+				}
+			}
+			n.SetFingersIndexes(fingersIndexes)
+			return true
+		}
 	}
-}
-
-func (n *NodeMock) Lookup(fromNodeIndex int, key []byte) (int, bool) {
-	keyBigInt := big.NewInt(0)
-	keyBigInt.SetBytes(key)
-	if n.belongToIncludedTop(keyBigInt, n.guid.BigInt(), n.fingerTable[0].fingerID.BigInt()) {
-		return n.fingerTable[0].simIndex, true
-	} else {
-		for i := len(n.fingerTable) - 1; i >= 0; i-- {
-			if n.belongToExcludedLimits(n.fingerTable[i].fingerID.BigInt(), n.guid.BigInt(), keyBigInt) {
-				return n.fingerTable[i].simIndex, false
+	return false
+}
+
+// RedirectFingers points the fingers that point to the old node, and whose target is in ]bottom, top], to the
+// new node. It returns true if any finger was updated.
+func (n *NodeMock) RedirectFingers(oldIndex, newIndex int, bottom, top ringID) bool {
+	for _, segment := range n.fingers {
+		if int(segment.index) == oldIndex {
+			updated := false
+			fingersIndexes := n.FingersIndexes()
+			for finger := range fingersIndexes {
+				if int(fingersIndexes[finger]) == oldIndex && belongToIncludedTop(n.FingerTarget(finger), bottom, top) {
+					fingersIndexes[finger] = int32(newIndex)
+					updated = true
+				}
 			}
+			if updated {
+				n.SetFingersIndexes(fingersIndexes)
+			}
+			return updated
 		}
 	}
-	return fromNodeIndex, false
+	return false
 }
 
+// Bytes returns the node's GUID bytes representation (padded to the GUID's size like guid.GUID's Bytes).
 func (n *NodeMock) Bytes() []byte {
-	return n.guid.Bytes()
+	return n.id.FillBytes(make([]byte, guid.SizeBytes()))
 }
 
-func (n *NodeMock) IP() string {
-	return n.ip
+// NodeIndex returns the index of the (physical) node that owns this position of the ring.
+func (n *NodeMock) NodeIndex() int {
+	return int(n.nodeIndex)
 }
 
 func (n *NodeMock) String() string {
-	return n.guid.String()
-}
-
-func (n *NodeMock) Equals(nodeArg *NodeMock) bool {
-	return n.guid.Equals(*nodeArg.guid)
+	return n.id.String()
 }
 
 func (n *NodeMock) Smaller(nodeArg *NodeMock) bool {
-	return n.guid.Cmp(*nodeArg.guid) < 0
-}
-
-func (n *NodeMock) HigherOrEqual(nodeArg *NodeMock) bool {
-	return n.guid.Cmp(*nodeArg.guid) >= 0
-}
-
-func (n *NodeMock) SetZeroGUID() {
-	n.guid = guid.NewGUIDInteger(0)
+	return n.id.Cmp(nodeArg.id) < 0
 }
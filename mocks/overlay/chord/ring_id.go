@@ -0,0 +1,185 @@
+package chord
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// maxRingIDBits maximum size of the ring's identifiers supported by the mock.
+const maxRingIDBits = 3 * 64
+
+var (
+	ringIDBits = 0        // Size of the ring's identifiers (in bits).
+	ringIDMask = ringID{} // Mask of the valid bits of the ring's identifiers.
+)
+
+// ringID is a fixed-size identifier of a ring's position (little-endian 64-bit words), used instead of the
+// big.Int backed GUIDs in order to keep the ring compact in memory. The arithmetic is modulo 2^ringIDBits.
+type ringID [3]uint64
+
+// Init sets the size (in bits) of the ring's identifiers.
+func Init(guidSizeBits int) {
+	if guidSizeBits <= 0 || guidSizeBits > maxRingIDBits {
+		panic(fmt.Errorf("chord's mock only supports GUIDs up to %d bits", maxRingIDBits))
+	}
+	ringIDBits = guidSizeBits
+	for w := range ringIDMask {
+		switch wordBits := guidSizeBits - w*64; {
+		case wordBits >= 64:
+			ringIDMask[w] = math.MaxUint64
+		case wordBits > 0:
+			ringIDMask[w] = (uint64(1) << uint(wordBits)) - 1
+		default:
+			ringIDMask[w] = 0
+		}
+	}
+}
+
+// newRingID creates a ring's identifier from a non-negative big integer (reduced modulo the ring's size).
+func newRingID(id *big.Int) ringID {
+	buffer := make([]byte, 8*len(ringID{}))
+	masked := big.NewInt(0).And(id, ringIDMask.BigInt())
+	return newRingIDBytes(masked.FillBytes(buffer))
+}
+
+// newRingIDBytes creates a ring's identifier from its big-endian bytes representation.
+func newRingIDBytes(idBytes []byte) ringID {
+	res := ringID{}
+	for i := 0; i < len(idBytes) && i < 8*len(res); i++ {
+		b := idBytes[len(idBytes)-1-i]
+		res[i/8] |= uint64(b) << uint(8*(i%8))
+	}
+	return res.masked()
+}
+
+// parseRingID parses the decimal representation of an identifier (e.g. a GUID's string) without allocating.
+// It returns false if the representation isn't a valid identifier of the ring.
+func parseRingID(decimal string) (ringID, bool) {
+	res := ringID{}
+	if decimal == "" {
+		return res, false
+	}
+	for i := 0; i < len(decimal); i++ {
+		digit := decimal[i] - '0'
+		if digit > 9 {
+			return res, false
+		}
+		carry := uint64(digit)
+		for w := range res {
+			high, low := bits.Mul64(res[w], 10)
+			var lowCarry uint64
+			res[w], lowCarry = bits.Add64(low, carry, 0)
+			carry = high + lowCarry
+		}
+		if carry != 0 {
+			return res, false
+		}
+	}
+	return res, res == res.masked()
+}
+
+// powerOfTwo returns the identifier 2^exponent.
+func powerOfTwo(exponent int) ringID {
+	res := ringID{}
+	res[exponent/64] = uint64(1) << uint(exponent%64)
+	return res.masked()
+}
+
+func (id ringID) masked() ringID {
+	for w := range id {
+		id[w] &= ringIDMask[w]
+	}
+	return id
+}
+
+// Add returns (id + other) mod 2^ringIDBits.
+func (id ringID) Add(other ringID) ringID {
+	res := ringID{}
+	carry := uint64(0)
+	for w := range id {
+		res[w], carry = bits.Add64(id[w], other[w], carry)
+	}
+	return res.masked()
+}
+
+// Sub returns (id - other) mod 2^ringIDBits, i.e. the clockwise distance from other to id.
+func (id ringID) Sub(other ringID) ringID {
+	res := ringID{}
+	borrow := uint64(0)
+	for w := range id {
+		res[w], borrow = bits.Sub64(id[w], other[w], borrow)
+	}
+	return res.masked()
+}
+
+// Cmp returns -1, 0 or +1 if the id is smaller, equal or higher than the other.
+func (id ringID) Cmp(other ringID) int {
+	for w := len(id) - 1; w >= 0; w-- {
+		if id[w] < other[w] {
+			return -1
+		} else if id[w] > other[w] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// BitLen returns the minimum number of bits needed to represent the id.
+func (id ringID) BitLen() int {
+	for w := len(id) - 1; w >= 0; w-- {
+		if id[w] != 0 {
+			return w*64 + bits.Len64(id[w])
+		}
+	}
+	return 0
+}
+
+// Fraction returns the fraction of the ring's size that the id represents.
+func (id ringID) Fraction() float64 {
+	res := 0.0
+	for w := range id {
+		res += math.Ldexp(float64(id[w]), w*64-ringIDBits)
+	}
+	return res
+}
+
+// FillBytes sets the buffer to the id's big-endian bytes representation (zero-padded) and returns it.
+// The higher bytes that don't fit in the buffer are discarded.
+func (id ringID) FillBytes(buffer []byte) []byte {
+	for i := range buffer {
+		buffer[len(buffer)-1-i] = 0
+		if i < 8*len(id) {
+			buffer[len(buffer)-1-i] = byte(id[i/8] >> uint(8*(i%8)))
+		}
+	}
+	return buffer
+}
+
+// BigInt returns a new big integer with the id's value.
+func (id ringID) BigInt() *big.Int {
+	return big.NewInt(0).SetBytes(id.FillBytes(make([]byte, 8*len(id))))
+}
+
+func (id ringID) String() string {
+	return id.BigInt().String()
+}
+
+// belongToExcludedLimits returns true if the key is in the ring's interval ]bottom, top[.
+func belongToExcludedLimits(key, bottom, top ringID) bool {
+	if bottom.Cmp(top) > 0 {
+		return (key.Cmp(top) < 0) || (key.Cmp(bottom) > 0)
+	} else {
+		return (key.Cmp(top) < 0) && (key.Cmp(bottom) > 0)
+	}
+}
+
+// belongToIncludedTop returns true if the key is in the ring's interval ]bottom, top].
+func belongToIncludedTop(key, bottom, top ringID) bool {
+	if bottom.Cmp(top) > 0 {
+		return (key.Cmp(top) <= 0) || (key.Cmp(bottom) > 0)
+	} else {
+		return (key.Cmp(top) <= 0) && (key.Cmp(bottom) > 0)
+	}
+}
@@ -0,0 +1,305 @@
+package kademlia
+
+import (
+	"context"
+	"crypto/sha1"
+	"github.com/ivpusic/grpool"
+	"github.com/pkg/errors"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/mocks/caravela"
+	"github.com/strabox/caravela-sim/mocks/network"
+	"github.com/strabox/caravela-sim/mocks/wire"
+	"github.com/strabox/caravela-sim/util"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"sort"
+)
+
+// kademliaLogTag kademlia's mock log tag.
+const kademliaLogTag = "SIM-KADEMLIA"
+
+// Mock mocks the interactions with a Kademlia overlay client simulating its functionality, i.e. the
+// XOR metric and the iterative lookups through the nodes' k-buckets.
+// All in memory, goroutine-safe.
+type Mock struct {
+	collector *metrics.Collector // Metrics collector.
+
+	numNodes            int                  // Number of nodes in the overlay.
+	idSizeBits          int                  // Size of the nodes' GUIDs in bits.
+	bucketSize          int                  // Maximum number of contacts in each k-bucket (k).
+	alpha               int                  // Number of nodes queried in parallel in each round of a lookup.
+	numSuccessors       int                  // Number of nodes returned by each lookup.
+	proximityCandidates int                  // Candidates considered for each contact (0 disables the PNS).
+	coordinates         *network.Coordinates // Network coordinates of the nodes (latency between them).
+	sizes               wire.SizeModel       // Measures the size of the messages.
+	rngSeed             int64                // Seed used to generate the nodes' identities and to fill the k-buckets.
+	msgSizes            messagesSizes        // Size of each kademlia's message.
+
+	nodes           []NodeMock     // Nodes sorted by GUID.
+	nodesPositions  []int          // Node's Index <-> Node's position in the sorted nodes.
+	nodesIdIndexMap map[string]int // ID <-> Node's position in the sorted nodes.
+	nodesIpIndexMap map[string]int // IP <-> Node's Index.
+}
+
+// NewKademliaMock creates a new kademlia overlay that can be used by an application component.
+// It implements the github.com/strabox/caravela/node/external Overlay interface.
+func NewKademliaMock(numNodes, idSizeBits, bucketSize, alpha, numSuccessors, proximityCandidates int,
+	coordinates *network.Coordinates, sizeModel wire.SizeModel, rngSeed int64, metricsCollector *metrics.Collector) *Mock {
+	return &Mock{
+		collector:           metricsCollector,
+		numNodes:            numNodes,
+		idSizeBits:          idSizeBits,
+		bucketSize:          bucketSize,
+		alpha:               alpha,
+		numSuccessors:       numSuccessors,
+		proximityCandidates: proximityCandidates,
+		coordinates:         coordinates,
+		sizes:               sizeModel,
+		rngSeed:             rngSeed,
+		nodes:               make([]NodeMock, numNodes),
+		nodesPositions:      make([]int, numNodes),
+		nodesIdIndexMap:     make(map[string]int),
+		nodesIpIndexMap:     make(map[string]int),
+	}
+}
+
+// Init initializes the kademlia's mock structure.
+func (m *Mock) Init() {
+	// The nodes' GUIDs are the hash of random identities, like a real kademlia deployment.
+	maxNumIDs := big.NewInt(0).Lsh(big.NewInt(1), uint(m.idSizeBits))
+	randomGenerator := rand.New(rand.NewSource(m.rngSeed))
+	identity := make([]byte, 16)
+	for i := range m.nodes {
+		randomGenerator.Read(identity)
+		hash := sha1.Sum(identity)
+		nodeGUID := big.NewInt(0).SetBytes(hash[:])
+		nodeGUID.Mod(nodeGUID, maxNumIDs)
+		if i == 0 { // The swarm backend needs the first node (master) with the GUID 0.
+			nodeGUID.SetInt64(0)
+		}
+		m.nodes[i] = *newNodeMock(nodeGUID, util.RandomIP(), i)
+	}
+
+	// Sort nodes by ID (ascending order), so the nodes of each k-bucket are contiguous.
+	sort.Slice(m.nodes, func(i, j int) bool {
+		return m.nodes[i].guid.BigInt().Cmp(m.nodes[j].guid.BigInt()) < 0
+	})
+	for i := range m.nodes {
+		m.nodesPositions[m.nodes[i].NodeIndex()] = i
+		m.nodesIdIndexMap[m.nodes[i].String()] = i
+		m.nodesIpIndexMap[m.nodes[i].IP()] = m.nodes[i].NodeIndex()
+	}
+	m.msgSizes = newMessagesSizes(m.sizes, len(m.nodes[0].Bytes()), m.bucketSize)
+
+	// Goroutine pool used to fill the k-buckets faster.
+	goroutinePool := grpool.NewPool(runtime.NumCPU(), runtime.NumCPU()*5)
+
+	for i := range m.nodes {
+		tempIndex := i
+		goroutinePool.WaitCount(1)
+		goroutinePool.JobQueue <- func() {
+			defer goroutinePool.JobDone()
+			randomGenerator := rand.New(rand.NewSource(m.rngSeed + int64(tempIndex) + 1))
+			m.nodes[tempIndex].fillBuckets(m.nodes, m.idSizeBits, m.bucketSize, m.proximityCandidates, m.coordinates,
+				randomGenerator)
+		}
+	}
+
+	goroutinePool.WaitAll()
+	goroutinePool.Release()
+
+	m.Print()
+}
+
+func (m *Mock) Print() {
+	util.Log.Debugf("##################################################################")
+	util.Log.Debugf("#                 KADEMLIA's MOCK CONFIGURATIONS                 #")
+	util.Log.Debugf("##################################################################")
+	util.Log.Debugf("Nodes:              	%d", m.numNodes)
+	util.Log.Debugf("GUID Size Bits:     	%d", m.idSizeBits)
+	util.Log.Debugf("Bucket Size:        	%d", m.bucketSize)
+	util.Log.Debugf("Alpha:              	%d", m.alpha)
+	util.Log.Debugf("Proximity Candidates:	%d", m.proximityCandidates)
+	util.Log.Debugf("##################################################################")
+}
+
+// Tick does nothing, the kademlia's membership is static during the simulation.
+func (m *Mock) Tick(_ int) {}
+
+// NodeIP returns the IP of the node with the given index.
+func (m *Mock) NodeIP(nodeIndex int) string {
+	return m.nodes[m.nodesPositions[nodeIndex]].IP()
+}
+
+// NodeTradersGUIDs returns the node's GUID, the kademlia's nodes have a single trader.
+func (m *Mock) NodeTradersGUIDs(nodeIndex int) [][]byte {
+	return [][]byte{m.nodes[m.nodesPositions[nodeIndex]].Bytes()}
+}
+
+// NodeIndexByIP returns the index of the node with the given IP.
+func (m *Mock) NodeIndexByIP(ip string) int {
+	index, exist := m.nodesIpIndexMap[ip]
+	if !exist {
+		panic(errors.New("Node's IP does not exist in the system!"))
+	}
+	return index
+}
+
+// NodeIndexByGUID returns the index of the node with the given GUID.
+func (m *Mock) NodeIndexByGUID(guid string) int {
+	return m.nodes[m.nodePosition(guid)].NodeIndex()
+}
+
+// nodePosition returns the position, in the sorted nodes, of the node with the given GUID.
+func (m *Mock) nodePosition(guid string) int {
+	position, exist := m.nodesIdIndexMap[guid]
+	if !exist {
+		panic(errors.New("Node's GUID does not exist in the system!"))
+	}
+	return position
+}
+
+// ArcLengths returns the fraction of the GUIDs' space owned by each node, i.e. the GUIDs closest (XOR) to
+// the node.
+func (m *Mock) ArcLengths() []float64 {
+	res := make([]float64, m.numNodes)
+	m.keySpaceShares(0, len(m.nodes), m.idSizeBits-1, 1, res)
+	return res
+}
+
+// keySpaceShares splits the share of the GUIDs' space closest to the nodes in [first, last[, that have the
+// same bits above the given bit, between the nodes with the bit 0 and the nodes with the bit 1.
+func (m *Mock) keySpaceShares(first, last, bit int, share float64, res []float64) {
+	if last-first == 1 || bit < 0 {
+		res[m.nodes[first].NodeIndex()] += share
+		return
+	}
+	middle := first + sort.Search(last-first, func(k int) bool { return m.nodes[first+k].guid.BigInt().Bit(bit) == 1 })
+	if middle == first || middle == last { // The GUIDs of the empty half are closer to the same nodes.
+		m.keySpaceShares(first, last, bit-1, share, res)
+		return
+	}
+	m.keySpaceShares(first, middle, bit-1, share/2, res)
+	m.keySpaceShares(middle, last, bit-1, share/2, res)
+}
+
+// ===============================================================================
+// =							  Overlay Interface                              =
+// ===============================================================================
+
+func (m *Mock) Create(_ context.Context, _ overlay.LocalNode) error {
+	// Do Nothing (Not necessary for the engine)
+	return nil
+}
+
+func (m *Mock) Join(_ context.Context, _ string, _ int, _ overlay.LocalNode) error {
+	// Do Nothing (Not necessary for the engine)
+	return nil
+}
+
+// Lookup does an iterative kademlia's lookup: in each round the alpha closest nodes to the key, not queried
+// yet, are asked for their closest contacts to the key. It ends when the closest nodes found were queried.
+// The queries of a round are parallel, so the round's latency is the longest round trip of the round.
+func (m *Mock) Lookup(ctx context.Context, key []byte) ([]*overlay.OverlayNode, error) {
+	fromNodeGUID := types.NodeGUID(ctx)
+	if fromNodeGUID == "" {
+		panic("Lookup message did not have the from node GUID debug data!")
+	}
+	fromPosition := m.nodePosition(fromNodeGUID)
+	fromNode := &m.nodes[fromPosition]
+	messagesPerReqAcc := 0
+	latencyAcc := 0.0
+	path := make([]int, 0)
+
+	keyBigInt := big.NewInt(0)
+	keyBigInt.SetBytes(key)
+
+	seen := map[int]bool{fromPosition: true}
+	queried := map[int]bool{fromPosition: true}
+	shortlist := append([]int{fromPosition}, fromNode.closestContacts(m.nodes, keyBigInt, m.bucketSize)...)
+	for _, position := range shortlist {
+		seen[position] = true
+	}
+
+	for {
+		shortlist = sortByDistance(m.nodes, shortlist, keyBigInt, m.bucketSize)
+
+		toQuery := make([]int, 0, m.alpha)
+		closestQueried := true
+		for i, position := range shortlist {
+			if queried[position] {
+				continue
+			}
+			if i < m.numSuccessors {
+				closestQueried = false
+			}
+			if len(toQuery) < m.alpha {
+				toQuery = append(toQuery, position)
+			}
+		}
+		if closestQueried || len(toQuery) == 0 {
+			break
+		}
+
+		roundLatency := 0.0
+		for _, position := range toQuery {
+			queried[position] = true
+			if roundTrip := 2 * m.coordinates.Latency(fromNode.NodeIndex(), m.nodes[position].NodeIndex()); roundTrip > roundLatency {
+				roundLatency = roundTrip
+			}
+			contacts := m.nodes[position].closestContacts(m.nodes, keyBigInt, m.bucketSize)
+			m.collector.LookupMessageReceived(fromNode.NodeIndex(), m.nodes[position].NodeIndex(), metrics.FindNodeMsg, 1, m.msgSizes.findNode)
+			m.collector.LookupMessageReceived(m.nodes[position].NodeIndex(), fromNode.NodeIndex(), metrics.FindNodeMsg, 1,
+				m.msgSizes.findNodeResponse[len(contacts)])
+			messagesPerReqAcc += 2
+			path = append(path, m.nodes[position].NodeIndex())
+			for _, contact := range contacts {
+				if !seen[contact] {
+					seen[contact] = true
+					shortlist = append(shortlist, contact)
+				}
+			}
+		}
+		latencyAcc += roundLatency
+	}
+	m.collector.LookupDone(fromNode.NodeIndex(), path, latencyAcc)
+	if messagesPerReqAcc > 0 {
+		m.collector.IncrMessagesTradedRequest(types.RequestID(ctx), messagesPerReqAcc)
+	}
+
+	numResults := m.numSuccessors
+	if len(shortlist) < numResults {
+		numResults = len(shortlist)
+	}
+	res := make([]*overlay.OverlayNode, numResults)
+	for i := range res {
+		node := &m.nodes[shortlist[i]]
+		res[i] = overlay.NewOverlayNode(node.IP(), caravela.FakePort, node.Bytes())
+	}
+	return res, nil
+}
+
+// Neighbors returns the node's two closest (XOR) contacts.
+func (m *Mock) Neighbors(_ context.Context, nodeID []byte) ([]*overlay.OverlayNode, error) {
+	node := &m.nodes[m.nodePosition(guid.NewGUIDBytes(nodeID).String())]
+	closest := node.closestContacts(m.nodes, node.guid.BigInt(), 2)
+	res := make([]*overlay.OverlayNode, len(closest))
+	for i, position := range closest {
+		res[i] = overlay.NewOverlayNode(m.nodes[position].IP(), caravela.FakePort, m.nodes[position].Bytes())
+	}
+	return res, nil
+}
+
+func (m *Mock) NodeID(_ context.Context) ([]byte, error) {
+	// Do Nothing (Not necessary for the engine)
+	return nil, nil
+}
+
+func (m *Mock) Leave(_ context.Context) error {
+	// Do Nothing (Not necessary for the engine)
+	return nil
+}
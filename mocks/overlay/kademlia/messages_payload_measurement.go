@@ -0,0 +1,58 @@
+package kademlia
+
+import (
+	"github.com/strabox/caravela-sim/mocks/wire"
+)
+
+// Types of the messages traded between the kademlia's nodes, used by the message's size models (e.g. the names
+// of the message's types in the fixed size model's configuration).
+const (
+	findNodeMsgType         = "FindNode"
+	findNodeResponseMsgType = "FindNodeResponse"
+)
+
+// Size (bytes) of the kademlia's messages in the REST encoding, estimated by the simulator and kept by the json
+// size model.
+const (
+	findNodeMessageSizeREST         = 100 // Num + Key + Node ID + IP address == 100 bytes (REST)
+	findNodeResponseContactSizeREST = 60  // Node ID + IP address == 60 bytes (REST) for each contact in the response
+)
+
+// findNodeMsg is the payload of a find node's request.
+type findNodeMsg struct {
+	Num    int    `json:"Num"`
+	Key    []byte `json:"Key"`
+	NodeID []byte `json:"NodeID"`
+	IP     string `json:"IP"`
+}
+
+// contactMsg is a contact of the find node's response.
+type contactMsg struct {
+	NodeID []byte `json:"NodeID"`
+	IP     string `json:"IP"`
+}
+
+// messagesSizes holds the size (bytes) of each kademlia's message.
+type messagesSizes struct {
+	findNode         int64
+	findNodeResponse []int64 // Number of contacts <-> Size of the response.
+}
+
+// newMessagesSizes measures the kademlia's messages, whose nodes' GUIDs have the given size (bytes), for
+// responses with up to maxContacts contacts. The messages only carry fixed size IDs and IPs, so they are measured
+// once, with representative IDs and IPs, instead of in each lookup.
+func newMessagesSizes(sizes wire.SizeModel, idSize int, maxContacts int) messagesSizes {
+	nodeID := wire.RepresentativeID(idSize)
+	findNode := &findNodeMsg{Num: 1, Key: nodeID, NodeID: nodeID, IP: wire.RepresentativeIP}
+	res := messagesSizes{
+		findNode:         int64(wire.EstimatedSize(sizes, findNodeMsgType, findNode, findNodeMessageSizeREST)),
+		findNodeResponse: make([]int64, maxContacts+1),
+	}
+	contacts := make([]contactMsg, 0, maxContacts)
+	for numContacts := range res.findNodeResponse {
+		res.findNodeResponse[numContacts] = int64(wire.EstimatedSize(sizes, findNodeResponseMsgType, contacts,
+			findNodeResponseContactSizeREST*numContacts))
+		contacts = append(contacts, contactMsg{NodeID: nodeID, IP: wire.RepresentativeIP})
+	}
+	return res
+}
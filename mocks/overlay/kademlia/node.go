@@ -0,0 +1,137 @@
+package kademlia
+
+import (
+	"github.com/strabox/caravela-sim/mocks/network"
+	"github.com/strabox/caravela/node/common/guid"
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// NodeMock represents a node of the kademlia's overlay with its routing table (k-buckets).
+type NodeMock struct {
+	guid      *guid.GUID
+	ip        string
+	nodeIndex int     // Index of the node in the simulation.
+	buckets   [][]int // Contacts (indexes in the sorted nodes) of each k-bucket, the bucket i holds the distances in [2^i, 2^(i+1)[.
+}
+
+func newNodeMock(guidBigInt *big.Int, ip string, nodeIndex int) *NodeMock {
+	return &NodeMock{
+		guid:      guid.NewGUIDBigInt(guidBigInt),
+		ip:        ip,
+		nodeIndex: nodeIndex,
+		buckets:   nil,
+	}
+}
+
+// distance returns the XOR distance between the node and the key.
+func (n *NodeMock) distance(key *big.Int) *big.Int {
+	return big.NewInt(0).Xor(n.guid.BigInt(), key)
+}
+
+// fillBuckets fills the node's k-buckets with up to bucketSize random contacts from the nodes sorted by GUID.
+// With the proximity neighbour selection (PNS) proximityCandidates random candidates are sampled for each
+// contact and the bucketSize candidates with the lowest latency to the node are kept.
+func (n *NodeMock) fillBuckets(nodes []NodeMock, idSizeBits, bucketSize, proximityCandidates int,
+	coordinates *network.Coordinates, randomGenerator *rand.Rand) {
+	numCandidates := bucketSize
+	if proximityCandidates > 1 {
+		numCandidates = bucketSize * proximityCandidates
+	}
+	n.buckets = make([][]int, idSizeBits)
+	for i := range n.buckets {
+		// The nodes at a distance in [2^i, 2^(i+1)[ share the bits above i and differ in the bit i.
+		bucketRange := big.NewInt(0).Lsh(big.NewInt(1), uint(i))
+		low := big.NewInt(0).Xor(n.guid.BigInt(), bucketRange)
+		low.Rsh(low, uint(i)).Lsh(low, uint(i))
+		high := big.NewInt(0).Add(low, bucketRange)
+
+		first := sort.Search(len(nodes), func(k int) bool { return nodes[k].guid.BigInt().Cmp(low) >= 0 })
+		last := sort.Search(len(nodes), func(k int) bool { return nodes[k].guid.BigInt().Cmp(high) >= 0 })
+		n.buckets[i] = sampleContacts(first, last, numCandidates, randomGenerator)
+		if len(n.buckets[i]) > bucketSize {
+			bucket := n.buckets[i]
+			sort.Slice(bucket, func(a, b int) bool {
+				return coordinates.Latency(n.nodeIndex, nodes[bucket[a]].nodeIndex) <
+					coordinates.Latency(n.nodeIndex, nodes[bucket[b]].nodeIndex)
+			})
+			n.buckets[i] = append([]int(nil), bucket[:bucketSize]...) // Releases the discarded candidates.
+		}
+	}
+}
+
+// sampleContacts returns up to bucketSize random indexes in [first, last[.
+func sampleContacts(first, last, bucketSize int, randomGenerator *rand.Rand) []int {
+	numCandidates := last - first
+	if numCandidates <= 0 {
+		return nil
+	}
+	if numCandidates <= bucketSize {
+		res := make([]int, numCandidates)
+		for i := range res {
+			res[i] = first + i
+		}
+		return res
+	}
+
+	// Floyd's sampling of bucketSize distinct candidates.
+	chosen := make(map[int]bool, bucketSize)
+	res := make([]int, 0, bucketSize)
+	for j := numCandidates - bucketSize; j < numCandidates; j++ {
+		candidate := randomGenerator.Intn(j + 1)
+		if chosen[candidate] {
+			candidate = j
+		}
+		chosen[candidate] = true
+		res = append(res, first+candidate)
+	}
+	return res
+}
+
+// closestContacts returns up to amount of the node's contacts closest to the key (ascending XOR distance).
+func (n *NodeMock) closestContacts(nodes []NodeMock, key *big.Int, amount int) []int {
+	// The contacts in the key's bucket are the closest ones, then the ones in the lower buckets and then the
+	// ones in each higher bucket.
+	keyBucket := n.distance(key).BitLen() - 1
+	candidates := make([]int, 0, amount)
+	for i := 0; i <= keyBucket && i < len(n.buckets); i++ {
+		candidates = append(candidates, n.buckets[i]...)
+	}
+	for i := keyBucket + 1; i < len(n.buckets) && len(candidates) < amount; i++ {
+		candidates = append(candidates, n.buckets[i]...)
+	}
+	return sortByDistance(nodes, candidates, key, amount)
+}
+
+// sortByDistance sorts the nodes' indexes by the XOR distance to the key, returning up to amount of them.
+func sortByDistance(nodes []NodeMock, indexes []int, key *big.Int, amount int) []int {
+	distances := make(map[int]*big.Int, len(indexes))
+	for _, index := range indexes {
+		distances[index] = nodes[index].distance(key)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return distances[indexes[i]].Cmp(distances[indexes[j]]) < 0
+	})
+	if len(indexes) > amount {
+		return indexes[:amount]
+	}
+	return indexes
+}
+
+func (n *NodeMock) Bytes() []byte {
+	return n.guid.Bytes()
+}
+
+func (n *NodeMock) IP() string {
+	return n.ip
+}
+
+// NodeIndex returns the index of the node in the simulation.
+func (n *NodeMock) NodeIndex() int {
+	return n.nodeIndex
+}
+
+func (n *NodeMock) String() string {
+	return n.guid.String()
+}
@@ -0,0 +1,24 @@
+package overlay
+
+import (
+	caravelaOverlay "github.com/strabox/caravela/overlay"
+)
+
+// Mock represents an overlay mock that "connects" all the simulated nodes.
+// Besides the CARAVELA's overlay interface it provides the engine with the nodes' identities.
+type Mock interface {
+	caravelaOverlay.Overlay
+
+	// Tick updates the overlay's membership in each simulation tick (e.g. nodes joining and leaving).
+	Tick(tick int)
+	// NodeIP returns the IP of the node with the given index.
+	NodeIP(nodeIndex int) string
+	// NodeTradersGUIDs returns the GUIDs of the node's traders, the last one is the node's GUID.
+	NodeTradersGUIDs(nodeIndex int) [][]byte
+	// NodeIndexByIP returns the index of the node with the given IP.
+	NodeIndexByIP(ip string) int
+	// NodeIndexByGUID returns the index of the node that owns the given trader's GUID.
+	NodeIndexByGUID(guid string) int
+	// ArcLengths returns the fraction of the GUIDs' space owned by each node.
+	ArcLengths() []float64
+}
@@ -0,0 +1,92 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/engine/metrics"
+	"github.com/strabox/caravela-sim/mocks/network"
+	"github.com/strabox/caravela-sim/mocks/overlay/chord"
+	"github.com/strabox/caravela-sim/mocks/overlay/kademlia"
+	"github.com/strabox/caravela-sim/mocks/wire"
+	"github.com/strabox/caravela-sim/util"
+	caravelaConfigs "github.com/strabox/caravela/configuration"
+	"log"
+	"strings"
+)
+
+// MockFactory represents a method that creates new overlay mocks.
+type MockFactory func(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration,
+	coordinates *network.Coordinates, rngSeed int64, collector *metrics.Collector) (Mock, error)
+
+// mocks holds all the registered overlay mocks available.
+var mocks = make(map[string]MockFactory)
+
+// init initializes our predefined overlay mocks.
+func init() {
+	RegisterOverlayMock("chord", newChordMock)
+	RegisterOverlayMock("kademlia", newKademliaMock)
+}
+
+// RegisterOverlayMock can be used to register a new overlay mock in order to be available.
+func RegisterOverlayMock(overlayName string, factory MockFactory) {
+	if factory == nil {
+		log.Panic("nil overlay mock registering")
+	}
+	_, exist := mocks[overlayName]
+	if exist {
+		util.Log.Warnf("overlay mock %s is being overridden", overlayName)
+	}
+	mocks[overlayName] = factory
+}
+
+// CreateOverlayMock is used to obtain an initialized overlay mock based on the configurations.
+func CreateOverlayMock(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration,
+	rngSeed int64, collector *metrics.Collector) Mock {
+	configuredOverlay := simConfigs.OverlayMock()
+
+	overlayFactory, exist := mocks[configuredOverlay]
+	if !exist {
+		existingOverlays := make([]string, 0, len(mocks))
+		for overlayName := range mocks {
+			existingOverlays = append(existingOverlays, overlayName)
+		}
+		err := errors.New(fmt.Sprintf("Invalid %s overlay mock. Overlays available: %s",
+			configuredOverlay, strings.Join(existingOverlays, ", ")))
+		log.Panic(err)
+	}
+
+	// The coordinates use a random stream different from the ones used by the overlays.
+	coordinates := network.CreateCoordinates(simConfigs, rngSeed-1)
+
+	overlayMock, err := overlayFactory(simConfigs, caravelaConfigs, coordinates, rngSeed, collector)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return overlayMock
+}
+
+// newChordMock creates and initializes the chord's overlay mock.
+func newChordMock(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration,
+	coordinates *network.Coordinates, rngSeed int64, collector *metrics.Collector) (Mock, error) {
+	chord.Init(caravelaConfigs.ChordHashSizeBits())
+	chordMock := chord.NewChordMock(simConfigs.TotalNumberOfNodes(), caravelaConfigs.ChordVirtualNodes(),
+		caravelaConfigs.ChordNumSuccessors(), simConfigs.ProximityCandidates(), simConfigs.ChordMockGUIDPlacement(),
+		coordinates, wire.CreateUncachedSizeModel(simConfigs), rngSeed, collector)
+	chordMock.Init()
+	if simConfigs.ChordMockChurn() {
+		chordMock.InitChurn(simConfigs)
+	}
+	return chordMock, nil
+}
+
+// newKademliaMock creates and initializes the kademlia's overlay mock.
+func newKademliaMock(simConfigs *configuration.Configuration, caravelaConfigs *caravelaConfigs.Configuration,
+	coordinates *network.Coordinates, rngSeed int64, collector *metrics.Collector) (Mock, error) {
+	kademliaMock := kademlia.NewKademliaMock(simConfigs.TotalNumberOfNodes(), caravelaConfigs.ChordHashSizeBits(),
+		simConfigs.KademliaMockBucketSize(), simConfigs.KademliaMockAlpha(), caravelaConfigs.ChordNumSuccessors(),
+		simConfigs.ProximityCandidates(), coordinates, wire.CreateUncachedSizeModel(simConfigs), rngSeed, collector)
+	kademliaMock.Init()
+	return kademliaMock, nil
+}
@@ -0,0 +1,100 @@
+package wire
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+	"math/bits"
+	"reflect"
+	"sync"
+)
+
+// binarySizeModel estimates the size of the messages in a compact binary encoding (like protocol buffers):
+// the integers are varints, the floats have a fixed size and the strings, slices and maps are prefixed by
+// their length. The structs' fields are encoded in order, without names, and the fields ignored by the JSON
+// encoding (tag "-") aren't encoded.
+type binarySizeModel struct {
+	structsFields sync.Map // Struct's type <-> Indexes of its encoded fields (cached per type).
+}
+
+func newBinarySizeModel(_ *configuration.Configuration) (SizeModel, error) {
+	return &binarySizeModel{}, nil
+}
+
+func (b *binarySizeModel) Size(_ string, msg interface{}) int {
+	return b.sizeOf(reflect.ValueOf(msg))
+}
+
+func (b *binarySizeModel) sizeOf(value reflect.Value) int {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.Bool, reflect.Int8, reflect.Uint8:
+		return 1
+	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed := value.Int()
+		return varintSize(uint64((signed << 1) ^ (signed >> 63))) // Zig-zag encoding.
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return varintSize(value.Uint())
+	case reflect.Float32:
+		return 4
+	case reflect.Float64:
+		return 8
+	case reflect.String:
+		return varintSize(uint64(value.Len())) + value.Len()
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return 1 // Presence's flag.
+		}
+		return 1 + b.sizeOf(value.Elem())
+	case reflect.Slice, reflect.Array:
+		res := varintSize(uint64(value.Len()))
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return res + value.Len()
+		}
+		for i := 0; i < value.Len(); i++ {
+			res += b.sizeOf(value.Index(i))
+		}
+		return res
+	case reflect.Map:
+		res := varintSize(uint64(value.Len()))
+		for _, key := range value.MapKeys() {
+			res += b.sizeOf(key) + b.sizeOf(value.MapIndex(key))
+		}
+		return res
+	case reflect.Struct:
+		res := 0
+		for _, field := range b.structFields(value.Type()) {
+			res += b.sizeOf(value.Field(field))
+		}
+		return res
+	default: // Channels and functions aren't encoded.
+		return 0
+	}
+}
+
+// structFields returns the indexes of the struct's fields that are encoded.
+func (b *binarySizeModel) structFields(structType reflect.Type) []int {
+	if fields, exist := b.structsFields.Load(structType); exist {
+		return fields.([]int)
+	}
+	fields := make([]int, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous { // Unexported field.
+			continue
+		}
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	b.structsFields.Store(structType, fields)
+	return fields
+}
+
+// varintSize returns the number of bytes of the value's varint encoding.
+func varintSize(value uint64) int {
+	if value == 0 {
+		return 1
+	}
+	return (bits.Len64(value) + 6) / 7
+}
@@ -0,0 +1,58 @@
+package wire
+
+import (
+	"sync"
+)
+
+// cachedSizeModel measures the first messages of each type with the wrapped model and after that it
+// estimates the size of the type's messages as the average size of the measured ones, which avoids
+// encoding the messages in the simulation's hot path.
+type cachedSizeModel struct {
+	sizeModel SizeModel // Model used to measure the samples.
+	samples   int       // Messages measured per type before using the type's average size.
+
+	estimates      map[string]*sizeEstimate // Message's type <-> Estimate of its size.
+	estimatesMutex sync.Mutex
+}
+
+// sizeEstimate holds the measurements of a message's type.
+type sizeEstimate struct {
+	measured  int // Number of messages measured.
+	totalSize int // Sum of the measured messages' size.
+	average   int // Average size of the measured messages (only valid after all the samples).
+}
+
+func newCachedSizeModel(sizeModel SizeModel, samples int) *cachedSizeModel {
+	return &cachedSizeModel{
+		sizeModel: sizeModel,
+		samples:   samples,
+		estimates: make(map[string]*sizeEstimate),
+	}
+}
+
+func (c *cachedSizeModel) Size(msgType string, msg interface{}) int {
+	c.estimatesMutex.Lock()
+	estimate, exist := c.estimates[msgType]
+	if !exist {
+		estimate = &sizeEstimate{}
+		c.estimates[msgType] = estimate
+	}
+	if estimate.measured >= c.samples {
+		c.estimatesMutex.Unlock()
+		return estimate.average
+	}
+	c.estimatesMutex.Unlock()
+
+	size := c.sizeModel.Size(msgType, msg)
+
+	c.estimatesMutex.Lock()
+	defer c.estimatesMutex.Unlock()
+	if estimate.measured < c.samples {
+		estimate.measured++
+		estimate.totalSize += size
+		if estimate.measured == c.samples {
+			estimate.average = (estimate.totalSize + c.samples/2) / c.samples
+		}
+	}
+	return size
+}
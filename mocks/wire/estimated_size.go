@@ -0,0 +1,44 @@
+package wire
+
+import (
+	"math/rand"
+)
+
+// RepresentativeIP is the IP address carried by the payloads that are measured once to represent all the
+// messages of a type (the longest IPv4 address), so the sizes don't depend on the IPs drawn in each run.
+const RepresentativeIP = "255.255.255.255"
+
+// representativeIDSeed is the seed of the random bytes of the representative identifiers.
+const representativeIDSeed = 0
+
+// RepresentativeID returns an identifier with the given size (bytes), carried by the payloads that are
+// measured once to represent all the messages of a type. The bytes are random, like the nodes' GUIDs, but
+// the same in every run.
+func RepresentativeID(size int) []byte {
+	id := make([]byte, size)
+	rand.New(rand.NewSource(representativeIDSeed)).Read(id)
+	return id
+}
+
+// EstimatedSize returns the size of a message that CARAVELA doesn't trade through its REST API (e.g. the
+// overlay's messages and the acknowledgements). The json model keeps the REST size estimated by the simulator
+// for it, so the bandwidth with the default model stays comparable with the previous results, and the other
+// models measure the message's payload.
+func EstimatedSize(sizeModel SizeModel, msgType string, msg interface{}, restSize int) int {
+	if isJSONSizeModel(sizeModel) {
+		return restSize
+	}
+	return sizeModel.Size(msgType, msg)
+}
+
+// isJSONSizeModel returns true if the size model is the json one, with or without the cache.
+func isJSONSizeModel(sizeModel SizeModel) bool {
+	switch model := sizeModel.(type) {
+	case *jsonSizeModel:
+		return true
+	case *cachedSizeModel:
+		return isJSONSizeModel(model.sizeModel)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,20 @@
+package wire
+
+import (
+	"github.com/strabox/caravela-sim/configuration"
+)
+
+// fixedSizeModel gives a configured size to each message's type, independently of the message's content.
+type fixedSizeModel struct {
+	simConfigs *configuration.Configuration
+}
+
+func newFixedSizeModel(simConfigs *configuration.Configuration) (SizeModel, error) {
+	return &fixedSizeModel{
+		simConfigs: simConfigs,
+	}, nil
+}
+
+func (f *fixedSizeModel) Size(msgType string, _ interface{}) int {
+	return f.simConfigs.MessageFixedSize(msgType)
+}
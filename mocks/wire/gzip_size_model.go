@@ -0,0 +1,45 @@
+package wire
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/strabox/caravela-sim/configuration"
+	"io/ioutil"
+	"sync"
+)
+
+// gzipSizeModel measures the messages by their gzip compressed JSON encoding, i.e. the CARAVELA's REST API
+// with compressed bodies.
+type gzipSizeModel struct {
+	writers sync.Pool // Reusable gzip writers (they are expensive to create).
+}
+
+func newGzipSizeModel(_ *configuration.Configuration) (SizeModel, error) {
+	return &gzipSizeModel{
+		writers: sync.Pool{
+			New: func() interface{} { return gzip.NewWriter(ioutil.Discard) },
+		},
+	}, nil
+}
+
+func (g *gzipSizeModel) Size(_ string, msg interface{}) int {
+	jsonBytes, _ := json.Marshal(msg)
+
+	counter := &byteCounter{}
+	writer := g.writers.Get().(*gzip.Writer)
+	writer.Reset(counter)
+	writer.Write(jsonBytes)
+	writer.Close()
+	g.writers.Put(writer)
+	return counter.bytes
+}
+
+// byteCounter is a writer that only counts the bytes written.
+type byteCounter struct {
+	bytes int
+}
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	b.bytes += len(p)
+	return len(p), nil
+}
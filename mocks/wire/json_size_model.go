@@ -0,0 +1,18 @@
+package wire
+
+import (
+	"encoding/json"
+	"github.com/strabox/caravela-sim/configuration"
+)
+
+// jsonSizeModel measures the messages by their JSON encoding, i.e. the CARAVELA's REST API.
+type jsonSizeModel struct{}
+
+func newJSONSizeModel(_ *configuration.Configuration) (SizeModel, error) {
+	return &jsonSizeModel{}, nil
+}
+
+func (j *jsonSizeModel) Size(_ string, msg interface{}) int {
+	jsonBytes, _ := json.Marshal(msg)
+	return len(jsonBytes)
+}
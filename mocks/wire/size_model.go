@@ -0,0 +1,9 @@
+package wire
+
+// SizeModel measures the size (in bytes) that the messages traded between the nodes would have on the wire,
+// given the message's type and its payload. Each model represents a different wire format of the protocol.
+// The implementations must be goroutine-safe.
+type SizeModel interface {
+	// Size returns the size (in bytes) of the message.
+	Size(msgType string, msg interface{}) int
+}
@@ -0,0 +1,69 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"github.com/strabox/caravela-sim/configuration"
+	"github.com/strabox/caravela-sim/util"
+	"log"
+	"strings"
+)
+
+// SizeModelFactory represents a method that creates new message's size models.
+type SizeModelFactory func(simConfigs *configuration.Configuration) (SizeModel, error)
+
+// sizeModels holds all the registered message's size models available.
+var sizeModels = make(map[string]SizeModelFactory)
+
+// init initializes our predefined message's size models.
+func init() {
+	RegisterSizeModel("json", newJSONSizeModel)
+	RegisterSizeModel("binary", newBinarySizeModel)
+	RegisterSizeModel("gzip", newGzipSizeModel)
+	RegisterSizeModel("fixed", newFixedSizeModel)
+}
+
+// RegisterSizeModel can be used to register a new message's size model in order to be available.
+func RegisterSizeModel(sizeModelName string, factory SizeModelFactory) {
+	if factory == nil {
+		log.Panic("nil message size model registering")
+	}
+	_, exist := sizeModels[sizeModelName]
+	if exist {
+		util.Log.Warnf("message size model %s is being overridden", sizeModelName)
+	}
+	sizeModels[sizeModelName] = factory
+}
+
+// CreateSizeModel is used to obtain a message's size model based on the configurations.
+// When configured, the model is wrapped by a cache of the average size of each message's type.
+func CreateSizeModel(simConfigs *configuration.Configuration) SizeModel {
+	sizeModel := CreateUncachedSizeModel(simConfigs)
+	if cacheSamples := simConfigs.MessageSizeCacheSamples(); cacheSamples > 0 {
+		return newCachedSizeModel(sizeModel, cacheSamples)
+	}
+	return sizeModel
+}
+
+// CreateUncachedSizeModel is used to obtain the configured message's size model without the cache, for the
+// messages that are measured only once.
+func CreateUncachedSizeModel(simConfigs *configuration.Configuration) SizeModel {
+	configuredSizeModel := simConfigs.MessageSizeModel()
+
+	sizeModelFactory, exist := sizeModels[configuredSizeModel]
+	if !exist {
+		existingSizeModels := make([]string, 0, len(sizeModels))
+		for sizeModelName := range sizeModels {
+			existingSizeModels = append(existingSizeModels, sizeModelName)
+		}
+		err := errors.New(fmt.Sprintf("Invalid %s message size model. Models available: %s",
+			configuredSizeModel, strings.Join(existingSizeModels, ", ")))
+		log.Panic(err)
+	}
+
+	sizeModel, err := sizeModelFactory(simConfigs)
+	if err != nil {
+		log.Panic(err)
+	}
+	return sizeModel
+}